@@ -7,6 +7,7 @@ package i18n
 import (
 	"fmt"
 	"io/ioutil"
+	"sort"
 
 	"github.com/hollson/i18n/internal/plural"
 	"golang.org/x/text/language"
@@ -27,6 +28,12 @@ type Bundle struct {
 	pluralRules      plural.Rules
 	tags             []language.Tag
 	matcher          language.Matcher
+	observers        []Observer
+	errorHandler     func(error)
+	placeholderFunc  PlaceholderFunc
+
+	// paths are the paths of the message files the messages were parsed from, by language tag and message id.
+	paths map[language.Tag]map[string]string
 }
 
 // artTag is the language tag used for artificial languages
@@ -52,6 +59,50 @@ func (b *Bundle) RegisterUnmarshalFunc(format string, unmarshalFunc UnmarshalFun
 	b.unmarshalFuncs[format] = unmarshalFunc
 }
 
+// PlaceholderFunc returns the text that LocalizeOrDefault returns
+// for a message that could not be localized at all.
+type PlaceholderFunc func(messageID string) string
+
+// DefaultPlaceholder returns the message id wrapped in double brackets, e.g. "[[Greeting]]".
+func DefaultPlaceholder(messageID string) string {
+	return "[[" + messageID + "]]"
+}
+
+// RegisterPlaceholderFunc registers the PlaceholderFunc used by LocalizeOrDefault.
+// It defaults to DefaultPlaceholder.
+func (b *Bundle) RegisterPlaceholderFunc(placeholderFunc PlaceholderFunc) {
+	b.placeholderFunc = placeholderFunc
+}
+
+// RegisterErrorHandler registers a function that is called with the errors
+// that LocalizeOrDefault does not return to its caller.
+func (b *Bundle) RegisterErrorHandler(errorHandler func(error)) {
+	b.errorHandler = errorHandler
+}
+
+// PluralForm is a CLDR plural form such as "one" or "other".
+type PluralForm = plural.Form
+
+// RegisterPluralRule registers the plural rule for tag from conditions written in the CLDR plural rule syntax,
+// e.g. {"one": "n = 1 or t != 0 and i = 0,1", "other": ""}.
+// It is useful for constructed or minority languages that are not covered by CLDR,
+// and replaces any rule that tag previously had.
+func (b *Bundle) RegisterPluralRule(tag language.Tag, rules map[PluralForm]string) error {
+	rule, err := plural.ParseRule(rules)
+	if err != nil {
+		return fmt.Errorf("invalid plural rule for %s: %s", tag, err)
+	}
+	b.pluralRules[tag] = rule
+	return nil
+}
+
+// MustRegisterPluralRule is similar to RegisterPluralRule except it panics if an error happens.
+func (b *Bundle) MustRegisterPluralRule(tag language.Tag, rules map[PluralForm]string) {
+	if err := b.RegisterPluralRule(tag, rules); err != nil {
+		panic(err)
+	}
+}
+
 // LoadMessageFile loads the bytes from path
 // and then calls ParseMessageFileBytes.
 func (b *Bundle) LoadMessageFile(path string) (*MessageFile, error) {
@@ -80,7 +131,7 @@ func (b *Bundle) ParseMessageFileBytes(buf []byte, path string) (*MessageFile, e
 	if err != nil {
 		return nil, err
 	}
-	if err := b.AddMessages(messageFile.Tag, messageFile.Messages...); err != nil {
+	if err := b.addMessages(messageFile.Tag, path, messageFile.Messages); err != nil {
 		return nil, err
 	}
 	return messageFile, nil
@@ -97,6 +148,11 @@ func (b *Bundle) MustParseMessageFileBytes(buf []byte, path string) {
 // AddMessages adds messages for a language.
 // It is useful if your messages are in a format not supported by ParseMessageFileBytes.
 func (b *Bundle) AddMessages(tag language.Tag, messages ...*Message) error {
+	return b.addMessages(tag, "", messages)
+}
+
+// addMessages adds messages for a language that were parsed from the message file at path, if any.
+func (b *Bundle) addMessages(tag language.Tag, path string, messages []*Message) error {
 	pluralRule := b.pluralRules.Rule(tag)
 	if pluralRule == nil {
 		return fmt.Errorf("no plural rule registered for %s", tag)
@@ -108,8 +164,19 @@ func (b *Bundle) AddMessages(tag language.Tag, messages ...*Message) error {
 		b.messageTemplates[tag] = map[string]*MessageTemplate{}
 		b.addTag(tag)
 	}
+	if b.paths == nil {
+		b.paths = map[language.Tag]map[string]string{}
+	}
+	if b.paths[tag] == nil {
+		b.paths[tag] = map[string]string{}
+	}
 	for _, m := range messages {
 		b.messageTemplates[tag][m.ID] = NewMessageTemplate(m)
+		if path != "" {
+			b.paths[tag][m.ID] = path
+		} else {
+			delete(b.paths[tag], m.ID)
+		}
 	}
 	return nil
 }
@@ -145,3 +212,69 @@ func (b *Bundle) getMessageTemplate(tag language.Tag, id string) *MessageTemplat
 	}
 	return templates[id]
 }
+
+// pluralForms are the plural forms in CLDR order.
+var pluralForms = []PluralForm{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+
+// MessageIDs returns the sorted ids of the messages loaded for tag.
+func (b *Bundle) MessageIDs(tag language.Tag) []string {
+	ids := make([]string, 0, len(b.messageTemplates[tag]))
+	for id, template := range b.messageTemplates[tag] {
+		if template != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Message returns a copy of the message with id loaded for tag, or nil if there is none.
+func (b *Bundle) Message(tag language.Tag, id string) *Message {
+	template := b.getMessageTemplate(tag, id)
+	if template == nil {
+		return nil
+	}
+	m := *template.Message
+	m.Source = copyMap(m.Source)
+	m.Placeholders = copyMap(m.Placeholders)
+	m.Tags = append([]string(nil), m.Tags...)
+	m.DoNotTranslate = append([]string(nil), m.DoNotTranslate...)
+	return &m
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// PluralForms returns the plural forms of the message with id loaded for tag,
+// and the plural forms the plural rule of tag requires, both in CLDR order.
+// required is nil if there is no plural rule for tag.
+func (b *Bundle) PluralForms(tag language.Tag, id string) (present, required []PluralForm) {
+	template := b.getMessageTemplate(tag, id)
+	rule := b.pluralRules.Rule(tag)
+	for _, pluralForm := range pluralForms {
+		if template != nil && template.PluralTemplates[pluralForm] != nil {
+			present = append(present, pluralForm)
+		}
+		if rule == nil {
+			continue
+		}
+		if _, ok := rule.PluralForms[pluralForm]; ok {
+			required = append(required, pluralForm)
+		}
+	}
+	return present, required
+}
+
+// MessagePath returns the path of the message file the message with id loaded for tag was parsed from,
+// or "" if it was added by AddMessages.
+func (b *Bundle) MessagePath(tag language.Tag, id string) string {
+	return b.paths[tag][id]
+}
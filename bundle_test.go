@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestRegisterPluralRule(t *testing.T) {
+	tag := language.MustParse("tlh")
+	bundle := NewBundle(language.English)
+	messages := []*Message{{ID: "Cats", One: "{{.PluralCount}} cat", Few: "{{.PluralCount}} cats (few)", Other: "{{.PluralCount}} cats"}}
+	if err := bundle.AddMessages(tag, messages...); err == nil {
+		t.Fatalf("AddMessages succeeded for %s without a plural rule", tag)
+	}
+
+	if err := bundle.RegisterPluralRule(tag, map[PluralForm]string{"one": "n = 1", "few": "n = 2..4", "other": ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.AddMessages(tag, messages...); err != nil {
+		t.Fatalf("AddMessages failed for %s with a registered plural rule: %s", tag, err)
+	}
+	localizer := NewLocalizer(bundle, tag.String())
+	for count, expected := range map[int]string{1: "1 cat", 3: "3 cats (few)", 7: "7 cats"} {
+		localized, err := localizer.Localize(&LocalizeConfig{MessageID: "Cats", PluralCount: count})
+		if err != nil {
+			t.Errorf("Localize with count %d: %s", count, err)
+		} else if localized != expected {
+			t.Errorf("Localize with count %d = %q; expected %q", count, localized, expected)
+		}
+	}
+
+	if err := bundle.RegisterPluralRule(tag, map[PluralForm]string{"one": "n = "}); err == nil {
+		t.Errorf("RegisterPluralRule accepted a malformed rule")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustRegisterPluralRule did not panic for a malformed rule")
+		}
+	}()
+	bundle.MustRegisterPluralRule(tag, map[PluralForm]string{"one": "n = 1 or"})
+}
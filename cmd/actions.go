@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// placeholderPattern matches the placeholders protectActions puts in place of template actions.
+var placeholderPattern = regexp.MustCompile(`\{[0-9]+\}`)
+
+// protectActions replaces the template actions of src between leftDelim and rightDelim
+// with the numbered placeholders {0}, {1}, ..., which translation engines keep as they are.
+// It returns the protected text and the actions in the order of their placeholders.
+func protectActions(src, leftDelim, rightDelim string) (string, []string, error) {
+	actionPattern := templateActions(leftDelim, rightDelim)
+	if placeholderPattern.MatchString(actionPattern.ReplaceAllString(src, "")) {
+		return "", nil, fmt.Errorf("text contains a placeholder like {0} already")
+	}
+	var actions []string
+	text := actionPattern.ReplaceAllStringFunc(src, func(action string) string {
+		actions = append(actions, action)
+		return "{" + strconv.Itoa(len(actions)-1) + "}"
+	})
+	return text, actions, nil
+}
+
+// templateActions matches the actions of templates with the given delimiters.
+func templateActions(leftDelim, rightDelim string) *regexp.Regexp {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(leftDelim) + `(?s:.*?)` + regexp.QuoteMeta(rightDelim))
+}
+
+// restoreActions puts the template actions back in place of their placeholders in the translated text.
+// Every placeholder must occur exactly once.
+func restoreActions(text string, actions []string) (string, error) {
+	seen := make([]bool, len(actions))
+	var err error
+	restored := placeholderPattern.ReplaceAllStringFunc(text, func(p string) string {
+		i, _ := strconv.Atoi(p[1 : len(p)-1])
+		if i >= len(actions) {
+			err = fmt.Errorf("unknown placeholder %s", p)
+			return p
+		}
+		if seen[i] {
+			err = fmt.Errorf("placeholder %s occurs more than once", p)
+			return p
+		}
+		seen[i] = true
+		return actions[i]
+	})
+	if err != nil {
+		return "", err
+	}
+	for i, ok := range seen {
+		if !ok {
+			return "", fmt.Errorf("placeholder {%d} for %s is missing", i, actions[i])
+		}
+	}
+	return restored, nil
+}
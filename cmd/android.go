@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/internal/plural"
+)
+
+// xliffNamespace is the namespace of the <xliff:g> tags that name the placeholders of Android strings.
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+type androidString struct {
+	Name     string `xml:"name,attr"`
+	Quantity string `xml:"quantity,attr"`
+	Inner    string `xml:",innerxml"`
+}
+
+type androidPlurals struct {
+	Name  string          `xml:"name,attr"`
+	Items []androidString `xml:"item"`
+}
+
+// unmarshalAndroid is an i18n.UnmarshalFunc for Android strings.xml resource files.
+//
+// <string> elements become messages with an "other" form and <plurals> items become plural forms.
+// A comment right before an element becomes the description of its message,
+// and metadata comments like <!-- hash: sha256-... --> its metadata.
+// Placeholders like %1$s become {{.Name}} if they are wrapped in <xliff:g id="Name">,
+// {{.PluralCount}} if they are the first argument of a plural, or {{.Arg1}} otherwise.
+func unmarshalAndroid(data []byte, v interface{}) error {
+	raw, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("unsupported value %T", v)
+	}
+	messages := map[string]interface{}{}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	comment, metadata := "", map[string]interface{}{}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.Comment:
+			if depth != 1 {
+				continue
+			}
+			if !parseMetadataComment(string(t), metadata) {
+				comment = strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			depth--
+		case xml.StartElement:
+			if depth == 0 {
+				if t.Name.Local != "resources" {
+					return fmt.Errorf("expected <resources> but got <%s>", t.Name.Local)
+				}
+				depth++
+				continue
+			}
+			m := map[string]interface{}{}
+			switch t.Name.Local {
+			case "string":
+				var s androidString
+				if err := dec.DecodeElement(&s, &t); err != nil {
+					return err
+				}
+				src, err := androidTemplate(s.Inner, false)
+				if err != nil {
+					return fmt.Errorf("string %q: %s", s.Name, err)
+				}
+				m["id"], m["other"] = s.Name, src
+			case "plurals":
+				var p androidPlurals
+				if err := dec.DecodeElement(&p, &t); err != nil {
+					return err
+				}
+				for _, item := range p.Items {
+					src, err := androidTemplate(item.Inner, true)
+					if err != nil {
+						return fmt.Errorf("plurals %q: %s", p.Name, err)
+					}
+					m[item.Quantity] = src
+				}
+				m["id"] = p.Name
+			default:
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+				comment, metadata = "", map[string]interface{}{}
+				continue
+			}
+			if comment != "" {
+				m["description"] = comment
+			}
+			for k, v := range metadata {
+				m[k] = v
+			}
+			comment, metadata = "", map[string]interface{}{}
+			messages[m["id"].(string)] = m
+		}
+	}
+	*raw = messages
+	return nil
+}
+
+// androidTemplate converts the inner XML of an Android string to a template.
+func androidTemplate(inner string, isPlural bool) (string, error) {
+	dec := xml.NewDecoder(strings.NewReader(`<s xmlns:xliff="` + xliffNamespace + `">` + inner + `</s>`))
+	names := map[int]string{}
+	if isPlural {
+		names[1] = pluralCountField
+	}
+	text := &androidUnescaper{}
+	var gid string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			if gid != "" {
+				if m := printfSpecRegexp.FindStringSubmatch(string(t)); m != nil && m[0] != "%%" {
+					pos, _ := strconv.Atoi(m[1])
+					if pos == 0 {
+						pos = countUnpositionedSpecs(text.b.String()) + 1
+					}
+					names[pos] = gid
+				}
+			}
+			text.writeText(string(t))
+		case xml.StartElement:
+			switch {
+			case t.Name.Space == xliffNamespace && t.Name.Local == "g":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "id" {
+						gid = attr.Value
+					}
+				}
+			case t.Name.Local != "s":
+				// Keep styling tags such as <b> and <a href="..."> as they are.
+				text.writeMarkup(androidStartTag(t))
+			}
+		case xml.EndElement:
+			switch {
+			case t.Name.Space == xliffNamespace && t.Name.Local == "g":
+				gid = ""
+			case t.Name.Local != "s":
+				text.writeMarkup("</" + t.Name.Local + ">")
+			}
+		}
+	}
+	return fromPrintf(text.b.String(), names), nil
+}
+
+// androidStartTag returns the markup of a styling tag with its attributes.
+func androidStartTag(t xml.StartElement) string {
+	var b strings.Builder
+	b.WriteString("<" + t.Name.Local)
+	for _, attr := range t.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		fmt.Fprintf(&b, ` %s="%s"`, attr.Name.Local, xmlAttrEscape(attr.Value))
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func countUnpositionedSpecs(s string) int {
+	count := 0
+	for _, m := range printfSpecRegexp.FindAllStringSubmatch(s, -1) {
+		if m[0] != "%%" && m[1] == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// androidUnescaper resolves the escape sequences and quotes of the text of an Android string
+// and collapses whitespace outside of quotes like Android does. Styling tags are kept as they are.
+type androidUnescaper struct {
+	b             strings.Builder
+	quoted, space bool
+}
+
+func (u *androidUnescaper) write(r rune) {
+	u.flush()
+	u.b.WriteRune(r)
+}
+
+// flush writes the pending collapsed whitespace.
+func (u *androidUnescaper) flush() {
+	if u.space {
+		u.b.WriteByte(' ')
+		u.space = false
+	}
+}
+
+// writeMarkup writes a styling tag.
+func (u *androidUnescaper) writeMarkup(markup string) {
+	u.flush()
+	u.b.WriteString(markup)
+}
+
+// writeText writes the text s.
+func (u *androidUnescaper) writeText(s string) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case r == '\\' && i < len(s):
+			c := s[i]
+			i++
+			switch c {
+			case 'n':
+				u.write('\n')
+			case 't':
+				u.write('\t')
+			case 'u':
+				if i+4 <= len(s) {
+					if v, err := strconv.ParseUint(s[i:i+4], 16, 32); err == nil {
+						u.write(rune(v))
+						i += 4
+						continue
+					}
+				}
+				u.write('u')
+			default:
+				u.write(rune(c))
+			}
+		case r == '"':
+			u.quoted = !u.quoted
+		case !u.quoted && (r == ' ' || r == '\n' || r == '\t' || r == '\r'):
+			u.space = u.b.Len() > 0
+		default:
+			u.write(r)
+		}
+	}
+}
+
+// marshalAndroid returns the Android strings.xml resource file for messageTemplates.
+// Messages with a single "other" form become <string> elements, all others become <plurals>.
+func marshalAndroid(messageTemplates map[string]*i18n.MessageTemplate) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<resources xmlns:xliff="` + xliffNamespace + `">` + "\n")
+	for _, id := range sortedIDs(messageTemplates) {
+		mt := messageTemplates[id]
+		args := printfArgs(mt)
+		if mt.Desc != "" {
+			fmt.Fprintf(&buf, "    <!-- %s -->\n", strings.Replace(mt.Desc, "--", "- -", -1))
+		}
+		for _, comment := range metadataComments(mt) {
+			fmt.Fprintf(&buf, "    <!-- %s -->\n", comment)
+		}
+		if other := mt.PluralTemplates[plural.Other]; other != nil && len(mt.PluralTemplates) == 1 {
+			fmt.Fprintf(&buf, "    <string name=\"%s\">%s</string>\n", xmlAttrEscape(id), androidInnerXML(mt, other.Src, args))
+			continue
+		}
+		fmt.Fprintf(&buf, "    <plurals name=\"%s\">\n", xmlAttrEscape(id))
+		for _, form := range pluralForms {
+			if t := mt.PluralTemplates[form]; t != nil {
+				fmt.Fprintf(&buf, "        <item quantity=\"%s\">%s</item>\n", form, androidInnerXML(mt, t.Src, args))
+			}
+		}
+		buf.WriteString("    </plurals>\n")
+	}
+	buf.WriteString("</resources>\n")
+	return buf.Bytes(), nil
+}
+
+// androidInnerXML converts the template src of mt to the inner XML of an Android string.
+func androidInnerXML(mt *i18n.MessageTemplate, src string, args map[string]int) string {
+	var b strings.Builder
+	quote := strings.TrimSpace(src) != src || strings.Contains(src, "  ")
+	if quote {
+		b.WriteByte('"')
+	}
+	for i, seg := range splitFields(src, fieldActionRegexp(mt.LeftDelim, mt.RightDelim)) {
+		if seg.field != "" {
+			fmt.Fprintf(&b, `<xliff:g id="%s">%%%d$%s</xliff:g>`, xmlAttrEscape(seg.field), args[seg.field], printfVerb(seg.field, "s"))
+			continue
+		}
+		// Styling tags are written as markup and only the text around them is escaped.
+		start := 0
+		for _, loc := range androidMarkupRegexp.FindAllStringIndex(seg.text, -1) {
+			b.WriteString(xmlEscape(androidEscape(seg.text[start:loc[0]], i == 0 && start == 0 && !quote)))
+			b.WriteString(seg.text[loc[0]:loc[1]])
+			start = loc[1]
+		}
+		b.WriteString(xmlEscape(androidEscape(seg.text[start:], i == 0 && start == 0 && !quote)))
+	}
+	if quote {
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// androidEscape escapes the characters that have a special meaning in Android strings.
+// % is always escaped since unmarshalAndroid reads every string as a format string.
+func androidEscape(s string, first bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\', '\'', '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '@', '?':
+			if first && i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		case '%':
+			b.WriteString("%%")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// androidMarkupRegexp matches the styling tags of Android strings, such as <b>, </b> or <a href="...">.
+var androidMarkupRegexp = regexp.MustCompile(`</?[A-Za-z][\w.:-]*(\s+[\w.:-]+="[^"<>]*")*\s*/?>`)
+
+// Comments store the metadata merge needs to detect stale translations in the formats that have no fields for it,
+// e.g. <!-- hash: sha256-... --> and <!-- fuzzy --> in strings.xml.
+const (
+	hashCommentPrefix = "hash: "
+	fuzzyComment      = "fuzzy"
+)
+
+// argsCommentPrefix starts the comment that names the printf arguments of a message in the iOS formats,
+// which have no equivalent of <xliff:g id="Name">, e.g. /* args: PluralCount, Name */.
+const argsCommentPrefix = "args: "
+
+// argsComment returns the comment that names the printf arguments args, or "" if there are none.
+func argsComment(args map[string]int) string {
+	if len(args) == 0 {
+		return ""
+	}
+	names := make([]string, len(args))
+	for name, pos := range args {
+		names[pos-1] = name
+	}
+	return argsCommentPrefix + strings.Join(names, ", ")
+}
+
+// parseArgsComment returns the names of the printf arguments by position if comment is an args comment.
+func parseArgsComment(comment string) (map[int]string, bool) {
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, argsCommentPrefix) {
+		return nil, false
+	}
+	names := map[int]string{}
+	for i, name := range strings.Split(comment[len(argsCommentPrefix):], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[i+1] = name
+		}
+	}
+	return names, true
+}
+
+// metadataComments returns the metadata comments of mt.
+func metadataComments(mt *i18n.MessageTemplate) []string {
+	var comments []string
+	if mt.Hash != "" {
+		comments = append(comments, hashCommentPrefix+mt.Hash)
+	}
+	if mt.Fuzzy {
+		comments = append(comments, fuzzyComment)
+	}
+	return comments
+}
+
+// parseMetadataComment adds the metadata stored in comment to metadata and tells whether it is a metadata comment.
+func parseMetadataComment(comment string, metadata map[string]interface{}) bool {
+	comment = strings.TrimSpace(comment)
+	switch {
+	case comment == fuzzyComment:
+		metadata["fuzzy"] = "true"
+	case strings.HasPrefix(comment, hashCommentPrefix):
+		metadata["hash"] = strings.TrimSpace(comment[len(hashCommentPrefix):])
+	default:
+		return false
+	}
+	return true
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+var xmlAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func xmlEscape(s string) string {
+	return xmlEscaper.Replace(s)
+}
+
+func xmlAttrEscape(s string) string {
+	return xmlAttrEscaper.Replace(s)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hollson/i18n"
+)
+
+// roundTrip marshals messages with marshal and returns the messages read back from the file in format by id.
+func roundTrip(t *testing.T, format string, marshal func(map[string]*i18n.MessageTemplate) ([]byte, error), messages []*i18n.Message) map[string]*i18n.Message {
+	t.Helper()
+	messageTemplates := map[string]*i18n.MessageTemplate{}
+	for _, m := range messages {
+		messageTemplates[m.ID] = i18n.NewMessageTemplate(m)
+	}
+	content, err := marshal(messageTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mf, err := i18n.ParseMessageFileBytes(content, "active.en."+format, unmarshalFuncs)
+	if err != nil {
+		t.Fatalf("failed to read back\n%s\n%s", content, err)
+	}
+	read := map[string]*i18n.Message{}
+	for _, m := range mf.Messages {
+		read[m.ID] = m
+	}
+	return read
+}
+
+// checkRoundTrip checks that the messages read back by roundTrip equal expected.
+func checkRoundTrip(t *testing.T, read map[string]*i18n.Message, expected []*i18n.Message) {
+	t.Helper()
+	if len(read) != len(expected) {
+		t.Errorf("read back %d messages; expected %d", len(read), len(expected))
+	}
+	for _, e := range expected {
+		m := read[e.ID]
+		if m == nil {
+			t.Errorf("message %s is missing", e.ID)
+			continue
+		}
+		if !reflect.DeepEqual(m, e) {
+			t.Errorf("message %s is\n%#v\nexpected\n%#v", e.ID, m, e)
+		}
+	}
+}
+
+// roundTripMessages covers placeholders, escaping, markup and metadata.
+var roundTripMessages = []*i18n.Message{
+	{ID: "Hello", Other: "Hello {{.Name}}, you have {{.Count}} points!"},
+	{ID: "Percent", Other: "20%fewer, 100%% sure"},
+	{ID: "Quotes", Desc: "Quotes and new lines", Other: "It's \"quoted\" \\ here\nand\ttabbed"},
+	{ID: "Unicode", Other: "Привет, 世界 ✓"},
+	{ID: "Question", Other: "?Question @home"},
+	{ID: "XML", Other: "Tom & Jerry < 3"},
+	{ID: "Padded", Other: "  padded  "},
+	{ID: "Cats", Hash: "sha256-abc", Fuzzy: true, One: "{{.Name}} has {{.PluralCount}} cat", Other: "{{.Name}} has {{.PluralCount}} cats"},
+}
+
+func TestAndroidRoundTrip(t *testing.T) {
+	messages := append(roundTripMessages, &i18n.Message{
+		ID:    "Styled",
+		Other: `<b>Bold</b> and <a href="https://example.com/?a=1">link</a> for {{.Name}}`,
+	})
+	checkRoundTrip(t, roundTrip(t, "xml", marshalAndroid, messages), messages)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/internal"
+	"github.com/hollson/i18n/internal/plural"
+	"golang.org/x/text/language"
+)
+
+func usageAddLanguage() {
+	fmt.Fprintf(os.Stderr, `添加目标语言:
+
+    为每个语言标签创建translate文件,其中包含源语言的所有消息,并按该语言的复数规则展开为需要翻译的复数形式
+
+Usage: i18n_cli add-language [Option]... <Tag>...
+
+Option:
+    -source
+      源语言, 如: en(默认),en-US,zh-Hant-CN
+    -out
+      消息文件所在路径,源语言的消息从该路径下的active文件读取
+    -format
+      消息文件的格式,支持: toml(默认), json, yaml
+    -nested
+      按消息ID中的命名空间输出嵌套结构,而非扁平的键
+    -separator
+      嵌套输出时消息ID的命名空间分隔符,默认为".",使用其他分隔符时消息ID中不能包含"."
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,命令行参数优先于配置
+
+Example:
+    i18n_cli add-language ru pl
+
+`)
+}
+
+type addLanguageCommand struct {
+	tags      []string
+	source    languageTag
+	out       string
+	format    string
+	nested    bool
+	separator string
+	cfg       *config
+}
+
+func (ac *addLanguageCommand) name() string {
+	return "add-language"
+}
+
+func (ac *addLanguageCommand) parse(args []string) error {
+	flags := flag.NewFlagSet("add-language", flag.ExitOnError)
+	flags.Usage = usageAddLanguage
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	ac.cfg = cfg
+	ac.source = cfg.source(language.English)
+	flags.Var(&ac.source, "source", "en")
+	flags.StringVar(&ac.out, "out", cfg.out("."), "")
+	flags.StringVar(&ac.format, "format", cfg.format("toml"), "")
+	flags.BoolVar(&ac.nested, "nested", cfg.Nested, "")
+	flags.StringVar(&ac.separator, "separator", cfg.separator(defaultNestedSeparator), "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ac.tags = flags.Args()
+	return nil
+}
+
+// nestedSeparator returns the separator of nested message ids, or "" to write message ids as flat keys.
+func (ac *addLanguageCommand) nestedSeparator() string {
+	if !ac.nested {
+		return ""
+	}
+	return ac.separator
+}
+
+func (ac *addLanguageCommand) execute() error {
+	if len(ac.tags) < 1 {
+		usageAddLanguage()
+		return nil
+	}
+	sourceTag := ac.source.Tag()
+	pluralRules := plural.DefaultRules()
+	var tags []language.Tag
+	for _, t := range ac.tags {
+		tag, err := language.Parse(t)
+		if err != nil {
+			return fmt.Errorf("invalid language tag %q: %s", t, err)
+		}
+		if tag == sourceTag {
+			return fmt.Errorf("%s is the source language", tag)
+		}
+		if pluralRules.Rule(tag) == nil {
+			return fmt.Errorf("no plural rule for language %s", tag)
+		}
+		for _, label := range []string{"active", "translate"} {
+			path := messageFilePath(ac.out, label, tag, ac.format)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use merge to update it", path)
+			}
+		}
+		tags = append(tags, tag)
+	}
+
+	sourceMessageTemplates, err := readSourceMessageTemplates(ac.cfg, sourceTag, ac.out, ac.format, ac.nestedSeparator())
+	if err != nil {
+		return err
+	}
+	separator := ac.nestedSeparator()
+	op := &fileSystemOp{writeFiles: map[string][]byte{}}
+	var reports []string
+	for _, tag := range tags {
+		pluralRule := pluralRules.Rule(tag)
+		translate := make(map[string]*i18n.MessageTemplate, len(sourceMessageTemplates))
+		plurals := 0
+		for id, src := range sourceMessageTemplates {
+			translate[id] = untranslatedDst(src, pluralRule)
+			if len(src.PluralTemplates) > 1 {
+				plurals++
+			}
+		}
+		path, content, err := writeFile(ac.out, "translate", tag, ac.format, translate, false, separator, nil)
+		if err != nil {
+			return err
+		}
+		op.writeFiles[path] = content
+		report := fmt.Sprintf("%s: %d messages to translate", path, len(translate))
+		if plurals > 0 {
+			report += fmt.Sprintf(", fill in the plural forms %s of %d plural messages", formList(pluralRule.PluralForms), plurals)
+		}
+		reports = append(reports, report)
+	}
+	if err := op.apply(); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		fmt.Println(report)
+	}
+	return nil
+}
+
+// readSourceMessageTemplates returns the messages of sourceTag from the message files of cfg,
+// or from the active file of sourceTag in out, with the ids of nested files split by nestedSeparator.
+func readSourceMessageTemplates(cfg *config, sourceTag language.Tag, out, format, nestedSeparator string) (map[string]*i18n.MessageTemplate, error) {
+	paths, err := cfg.messageFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = []string{messageFilePath(out, "active", sourceTag, format)}
+	}
+	templates := map[string]*i18n.MessageTemplate{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("no source messages at %s, run extract first", path)
+			}
+			return nil, err
+		}
+		mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		if mf.Tag != sourceTag {
+			continue
+		}
+		if err := splitNestedIDs(mf, content, nestedSeparator); err != nil {
+			return nil, fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		for _, m := range mf.Messages {
+			if template := i18n.NewMessageTemplate(m); template != nil {
+				template.Hash = hash(template)
+				templates[m.ID] = template
+			}
+		}
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no messages found for source locale %s", sourceTag)
+	}
+	return templates, nil
+}
+
+// formList returns the plural forms of set in CLDR order.
+func formList(set map[plural.Form]struct{}) string {
+	var forms []string
+	for _, form := range pluralForms {
+		if _, ok := set[form]; ok {
+			forms = append(forms, string(form))
+		}
+	}
+	return strings.Join(forms, ", ")
+}
+
+// untranslatedDst returns the translation of src for a new language,
+// with all plural forms the language needs left to translate.
+func untranslatedDst(src *i18n.MessageTemplate, pluralRule *plural.Rule) *i18n.MessageTemplate {
+	dst := newDst(src)
+	for pluralForm := range requiredForms(src, pluralRule) {
+		dst.PluralTemplates[pluralForm] = placeholder(src, pluralForm)
+	}
+	return dst
+}
+
+// placeholder returns the source text written for an untranslated plural form of src:
+// the same form of src if it has one, or its "other" form.
+func placeholder(src *i18n.MessageTemplate, pluralForm plural.Form) *internal.Template {
+	if t := src.PluralTemplates[pluralForm]; t != nil {
+		return t
+	}
+	return src.PluralTemplates[plural.Other]
+}
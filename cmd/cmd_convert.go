@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hollson/i18n"
+)
+
+func usageConvert() {
+	fmt.Fprintf(os.Stderr, `转换消息文件格式:
+
+    将消息文件转换为另一种格式,保留嵌套(nested)或v1数组布局,以及description、hash和自定义分隔符
+
+Usage: i18n_cli convert [Option]... <Param>...
+
+Option:
+    -format
+      输出消息的文件格式,支持: toml(默认), json, yaml, xml(Android), strings, stringsdict(iOS)
+    -out
+      文件输出路径,默认为输入文件所在目录
+
+Example:
+    i18n_cli convert -format toml active.en.yaml translate.zh.yaml
+
+`)
+}
+
+type convertCommand struct {
+	msgFiles []string
+	out      string
+	format   string
+}
+
+func (cc *convertCommand) name() string {
+	return "convert"
+}
+
+func (cc *convertCommand) parse(args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	flags.Usage = usageConvert
+
+	flags.StringVar(&cc.out, "out", "", "")
+	flags.StringVar(&cc.format, "format", "toml", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cc.msgFiles = flags.Args()
+	return nil
+}
+
+func (cc *convertCommand) execute() error {
+	if len(cc.msgFiles) < 1 {
+		usageConvert()
+		return nil
+	}
+	for _, path := range cc.msgFiles {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		outPath, outContent, err := convert(path, content, cc.out, cc.format)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(outPath, outContent, 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// layout is the way messages are arranged in a message file.
+type layout int
+
+const (
+	// flatLayout maps message ids to messages.
+	flatLayout layout = iota
+	// nestedLayout maps namespaces to nested namespaces or messages.
+	nestedLayout
+	// v1Layout is an array of messages.
+	v1Layout
+)
+
+// defaultNestedSeparator separates the namespaces of message ids read from nested message files.
+const defaultNestedSeparator = "."
+
+// convert returns the path and content of the message file at path converted to format.
+func convert(path string, content []byte, out, format string) (string, []byte, error) {
+	mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load message file %s: %s", path, err)
+	}
+	messageTemplates := make(map[string]*i18n.MessageTemplate, len(mf.Messages))
+	for _, m := range mf.Messages {
+		if template := i18n.NewMessageTemplate(m); template != nil {
+			messageTemplates[m.ID] = template
+		}
+	}
+
+	if out == "" {
+		out = filepath.Dir(path)
+	}
+	base := filepath.Base(path)
+	outPath := filepath.Join(out, strings.TrimSuffix(base, filepath.Ext(base))+"."+format)
+	if filepath.Clean(outPath) == filepath.Clean(path) {
+		return "", nil, fmt.Errorf("%s is already in %s format", path, format)
+	}
+
+	var outContent []byte
+	switch format {
+	case "xml":
+		outContent, err = marshalAndroid(messageTemplates)
+	case "strings":
+		outContent, err = marshalStrings(messageTemplates)
+	case "stringsdict":
+		outContent, err = marshalStringsdict(messageTemplates)
+	default:
+		var v interface{}
+		if v, err = layoutValue(mf, content, messageTemplates, format); err != nil {
+			return "", nil, fmt.Errorf("failed to convert %s: %s", path, err)
+		}
+		outContent, err = marshal(v, format, nil)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal %s to %s: %s", path, format, err)
+	}
+	return outPath, outContent, nil
+}
+
+// layoutValue returns the value to marshal for messageTemplates
+// in the same layout as the message file mf with the given content.
+func layoutValue(mf *i18n.MessageFile, content []byte, messageTemplates map[string]*i18n.MessageTemplate, format string) (interface{}, error) {
+	flat := make(map[string]interface{}, len(messageTemplates))
+	for id, template := range messageTemplates {
+		// Keep hashes of translations, but write messages without hash as compact as possible.
+		flat[id] = marshalMessage(template, template.Hash == "")
+	}
+	if len(content) == 0 {
+		return flat, nil
+	}
+
+	var raw interface{}
+	if err := unmarshalFuncs[mf.Format](content, &raw); err != nil {
+		return nil, err
+	}
+	switch messageLayout(raw, mf.Messages) {
+	case v1Layout:
+		if format == "toml" {
+			fmt.Fprintf(os.Stderr, "%s: toml does not support the v1 array layout, writing messages by id instead\n", mf.Path)
+			return flat, nil
+		}
+		return v1Value(messageTemplates), nil
+	case nestedLayout:
+		return nestValue(flat, defaultNestedSeparator)
+	default:
+		return flat, nil
+	}
+}
+
+// messageLayout returns the layout of raw, the unmarshaled content of a message file with messages.
+func messageLayout(raw interface{}, messages []*i18n.Message) layout {
+	keys := map[string]bool{}
+	switch data := raw.(type) {
+	case []interface{}:
+		return v1Layout
+	case map[string]interface{}:
+		for k := range data {
+			keys[k] = true
+		}
+	case map[interface{}]interface{}:
+		for k := range data {
+			if s, ok := k.(string); ok {
+				keys[s] = true
+			}
+		}
+	}
+	for _, m := range messages {
+		if !keys[m.ID] && strings.Contains(m.ID, defaultNestedSeparator) {
+			return nestedLayout
+		}
+	}
+	return flatLayout
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+func usageExport() {
+	fmt.Fprintf(os.Stderr, `导出消息文件:
+
+    将消息导出为前端框架的格式,目前支持i18next(JSON v4),每种语言写入<out>/<语言>/<ns>.json,
+    消息ID按"."嵌套,复数形式使用_one,_other等后缀,字段引用{{.Name}}转为{{Name}},{{.PluralCount}}转为{{count}},
+    无法表示的模板(函数,管道,条件等)将被跳过并输出警告
+
+Usage: i18n_cli export [Option]... [MessageFile]...
+
+Option:
+    -target
+      导出格式,支持: i18next(默认)
+    -out
+      导出目录,默认为当前路径
+    -ns
+      i18next的命名空间,即文件名,默认为translation
+    -dry-run
+      不修改文件,以unified diff格式输出将要进行的修改
+    -check
+      不修改文件,如果导出的文件与现有文件不一致则以非零状态退出
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,未指定MessageFile时导出配置中messages的文件
+
+Example:
+    i18n_cli export -target i18next -out web/public/locales active.*.toml
+
+`)
+}
+
+type exportCommand struct {
+	msgFiles  []string
+	target    string
+	out       string
+	namespace string
+	dryRun    bool
+	check     bool
+	cfg       *config
+}
+
+func (ec *exportCommand) name() string {
+	return "export"
+}
+
+func (ec *exportCommand) parse(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = usageExport
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	ec.cfg = cfg
+	flags.StringVar(&ec.target, "target", "i18next", "")
+	flags.StringVar(&ec.out, "out", ".", "")
+	flags.StringVar(&ec.namespace, "ns", "translation", "")
+	flags.BoolVar(&ec.dryRun, "dry-run", false, "")
+	flags.BoolVar(&ec.check, "check", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ec.msgFiles = flags.Args()
+	return nil
+}
+
+func (ec *exportCommand) execute() error {
+	if ec.target != "i18next" {
+		return fmt.Errorf("unsupported export target %q", ec.target)
+	}
+	if len(ec.msgFiles) < 1 {
+		files, err := ec.cfg.messageFiles()
+		if err != nil {
+			return err
+		}
+		ec.msgFiles = files
+	}
+	if len(ec.msgFiles) < 1 {
+		usageExport()
+		return nil
+	}
+
+	bundle := i18n.NewBundle(language.English)
+	var tags []language.Tag
+	seen := map[language.Tag]bool{}
+	for _, path := range ec.msgFiles {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+		if err != nil {
+			return fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		if err := bundle.AddMessages(mf.Tag, mf.Messages...); err != nil {
+			return fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		if !seen[mf.Tag] {
+			seen[mf.Tag] = true
+			tags = append(tags, mf.Tag)
+		}
+	}
+
+	op := &fileSystemOp{writeFiles: map[string][]byte{}}
+	for _, tag := range tags {
+		v, errs := bundle.ExportI18next(tag)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", tag, err)
+		}
+		// Keep characters like < and & of the texts readable.
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		op.writeFiles[filepath.Join(ec.out, tag.String(), ec.namespace+".json")] = buf.Bytes()
+	}
+	return op.run(ec.dryRun, ec.check)
+}
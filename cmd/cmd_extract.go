@@ -9,10 +9,13 @@ import (
     "io/ioutil"
     "os"
     "path/filepath"
+    "reflect"
     "strconv"
     "strings"
 
     "github.com/hollson/i18n"
+    "github.com/hollson/i18n/internal/structtag"
+    "golang.org/x/text/language"
 )
 
 func usageExtract() {
@@ -28,7 +31,18 @@ Option:
     -out directory
       将消息文件写入此目录,默认为当前路径。
     -format format
-      消息文件输出格式，支持json,toml(默认)和yaml,默认为toml
+      消息文件输出格式，支持json,toml(默认),yaml,xml(Android),strings和stringsdict(iOS),默认为toml
+    -nested
+      按消息ID中的命名空间(如 errors.db.timeout)输出嵌套结构,而非扁平的键
+    -separator separator
+      嵌套输出时消息ID的命名空间分隔符,默认为".",使用其他分隔符时消息ID中不能包含"."
+    -dry-run
+      不修改文件,以unified diff格式输出将要进行的修改
+    -check
+      不修改文件,如果提取的消息与现有文件不一致则以非零状态退出
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,命令行参数优先于配置
 
 Example:
     i18n_cli extract
@@ -41,6 +55,11 @@ type extractCommand struct {
     source languageTag
     out         string
     format         string
+    nested    bool
+    separator string
+    dryRun    bool
+    check     bool
+    cfg       *config
 }
 
 func (ec *extractCommand) name() string {
@@ -51,9 +70,19 @@ func (ec *extractCommand) parse(args []string) error {
     flags := flag.NewFlagSet("extract", flag.ExitOnError)
     flags.Usage = usageExtract
 
+    cfg, err := loadConfig()
+    if err != nil {
+        return err
+    }
+    ec.cfg = cfg
+    ec.source = cfg.source(language.English)
     flags.Var(&ec.source, "source", "en")
-    flags.StringVar(&ec.out, "out", ".", "")
-    flags.StringVar(&ec.format, "format", "toml", "")
+    flags.StringVar(&ec.out, "out", cfg.out("."), "")
+    flags.StringVar(&ec.format, "format", cfg.format("toml"), "")
+    flags.BoolVar(&ec.nested, "nested", cfg.Nested, "")
+    flags.StringVar(&ec.separator, "separator", cfg.separator(defaultNestedSeparator), "")
+    flags.BoolVar(&ec.dryRun, "dry-run", false, "")
+    flags.BoolVar(&ec.check, "check", false, "")
     if err := flags.Parse(args); err != nil {
         return err
     }
@@ -64,15 +93,23 @@ func (ec *extractCommand) parse(args []string) error {
 
 func (ec *extractCommand) execute() error {
     if len(ec.paths) == 0 {
-        ec.paths = []string{"."}
+        ec.paths = ec.cfg.paths([]string{"."})
     }
 
     messages := []*i18n.Message{}
+    fieldMessages := map[*i18n.Message]*structField{}
+    fieldTexts := map[string][]string{}
     for _, path := range ec.paths {
         if err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
             if err != nil {
                 return err
             }
+            if ec.cfg.ignored(path) {
+                if info.IsDir() {
+                    return filepath.SkipDir
+                }
+                return nil
+            }
             if info.IsDir() {
                 return nil
             }
@@ -89,31 +126,84 @@ func (ec *extractCommand) execute() error {
             if err != nil {
                 return err
             }
-            msgs, err := extractMessages(buf)
+            e, err := extractMessages(buf)
             if err != nil {
                 return err
             }
-            messages = append(messages, msgs...)
+            messages = append(messages, e.messages...)
+            for m, field := range e.fieldMessages {
+                fieldMessages[m] = field
+            }
+            for key, texts := range e.fieldTexts {
+                fieldTexts[key] = append(fieldTexts[key], texts...)
+            }
             return nil
         }); err != nil {
             return err
         }
     }
+    separator := ""
+    if ec.nested {
+        separator = ec.separator
+    }
+    // Keep the key order and comments of the file that is rewritten.
+    existing := map[string][]byte{}
+    path := messageFilePath(ec.out, "active", ec.source.Tag(), ec.format)
+    content, exists, err := readIfExists(path)
+    if err != nil {
+        return err
+    }
+    existingMessages := map[string]*i18n.Message{}
+    if exists {
+        existing[path] = content
+        mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+        if err != nil {
+            return fmt.Errorf("failed to load message file %s: %s", path, err)
+        }
+        if err := splitNestedIDs(mf, content, separator); err != nil {
+            return fmt.Errorf("failed to load message file %s: %s", path, err)
+        }
+        for _, m := range mf.Messages {
+            existingMessages[m.ID] = m
+        }
+    }
     messageTemplates := map[string]*i18n.MessageTemplate{}
     for _, m := range messages {
+        if field := fieldMessages[m]; field != nil {
+            if messageTemplates[m.ID] != nil {
+                // The text of the message is extracted elsewhere.
+                continue
+            }
+            if text, ok := fieldText(fieldTexts[field.key]); ok {
+                m.Other = text
+                if field.plural {
+                    m.One = text
+                }
+            } else if em := existingMessages[m.ID]; em != nil {
+                // Keep the text that was written into the active file.
+                m = em
+            } else {
+                fmt.Fprintf(os.Stderr, "warning: message %q of field %s has no text, add default= to its i18n tag or write the text into %s\n", m.ID, field.key, path)
+                continue
+            }
+        }
         if mt := i18n.NewMessageTemplate(m); mt != nil {
             messageTemplates[m.ID] = mt
         }
     }
-    path, content, err := writeFile(ec.out, "active", ec.source.Tag(), ec.format, messageTemplates, true)
+    for _, problem := range checkTemplates(messageTemplates, ec.cfg.TemplateFuncs) {
+        fmt.Fprintf(os.Stderr, "warning: %s\n", problem)
+    }
+    path, content, err = writeFile(ec.out, "active", ec.source.Tag(), ec.format, messageTemplates, true, separator, existing)
     if err != nil {
         return err
     }
-    return ioutil.WriteFile(path, content, 0666)
+    op := &fileSystemOp{writeFiles: map[string][]byte{path: content}}
+    return op.run(ec.dryRun, ec.check)
 }
 
 // extractMessages extracts messages from the bytes of a Go source file.
-func extractMessages(buf []byte) ([]*i18n.Message, error) {
+func extractMessages(buf []byte) (*extractor, error) {
     fset := token.NewFileSet()
     file, err := parser.ParseFile(fset, "", buf, parser.AllErrors)
     if err != nil {
@@ -121,16 +211,48 @@ func extractMessages(buf []byte) ([]*i18n.Message, error) {
     }
     extractor := newExtractor(file)
     ast.Walk(extractor, file)
-    return extractor.messages, nil
+    return extractor, nil
 }
 
 func newExtractor(file *ast.File) *extractor {
-    return &extractor{i18nPackageName: i18nPackageName(file)}
+    return &extractor{
+        i18nPackageName: i18nPackageName(file),
+        structNames:     map[*ast.StructType]string{},
+        fieldMessages:   map[*i18n.Message]*structField{},
+        fieldTexts:      map[string][]string{},
+    }
 }
 
 type extractor struct {
     i18nPackageName string
     messages        []*i18n.Message
+    // structNames are the names of the declared struct types.
+    structNames map[*ast.StructType]string
+    // fieldMessages are the messages of i18n tags without default text, whose text is the value of their field.
+    fieldMessages map[*i18n.Message]*structField
+    // fieldTexts are the string literals assigned to fields in composite literals, by structField.key.
+    fieldTexts map[string][]string
+}
+
+// structField is a struct field with an i18n tag.
+type structField struct {
+    // key is the name of the struct type and the field, e.g. "Inbox.Title".
+    key string
+    // plural tells whether the tag has a count.
+    plural bool
+}
+
+// fieldText returns the text of a field if all the string literals assigned to it are the same.
+func fieldText(texts []string) (string, bool) {
+    if len(texts) == 0 || texts[0] == "" {
+        return "", false
+    }
+    for _, text := range texts[1:] {
+        if text != texts[0] {
+            return "", false
+        }
+    }
+    return texts[0], true
 }
 
 func (e *extractor) Visit(node ast.Node) ast.Visitor {
@@ -139,18 +261,37 @@ func (e *extractor) Visit(node ast.Node) ast.Visitor {
 }
 
 func (e *extractor) extractMessages(node ast.Node) {
+    switch n := node.(type) {
+    case *ast.CallExpr:
+        e.extractErrorText(n)
+        return
+    case *ast.TypeSpec:
+        if st, ok := n.Type.(*ast.StructType); ok {
+            e.structNames[st] = n.Name.Name
+        }
+        return
+    case *ast.StructType:
+        e.extractStructTags(n)
+        return
+    }
     cl, ok := node.(*ast.CompositeLit)
     if !ok {
         return
     }
     switch t := cl.Type.(type) {
+    case *ast.Ident:
+        e.extractFieldTexts(t.Name, cl)
+    case *ast.StructType:
+        e.extractFieldTexts(e.structName(t), cl)
     case *ast.SelectorExpr:
         if !e.isMessageType(t) {
+            e.extractFieldTexts(t.Sel.Name, cl)
             return
         }
         e.extractMessage(cl)
     case *ast.ArrayType:
         if !e.isMessageType(t.Elt) {
+            e.extractElidedFieldTexts(t.Elt, cl.Elts)
             return
         }
         for _, el := range cl.Elts {
@@ -162,6 +303,13 @@ func (e *extractor) extractMessages(node ast.Node) {
         }
     case *ast.MapType:
         if !e.isMessageType(t.Value) {
+            var values []ast.Expr
+            for _, el := range cl.Elts {
+                if kve, ok := el.(*ast.KeyValueExpr); ok {
+                    values = append(values, kve.Value)
+                }
+            }
+            e.extractElidedFieldTexts(t.Value, values)
             return
         }
         for _, el := range cl.Elts {
@@ -178,6 +326,117 @@ func (e *extractor) extractMessages(node ast.Node) {
     }
 }
 
+// extractErrorText extracts the message of a call to i18n.NewErrorText, which takes the id and text of its message.
+// The messages of the other constructors of i18n.LocalizableError are Message literals.
+func (e *extractor) extractErrorText(call *ast.CallExpr) {
+    se, ok := call.Fun.(*ast.SelectorExpr)
+    if !ok || se.Sel.Name != "NewErrorText" || len(call.Args) < 2 {
+        return
+    }
+    if x, ok := se.X.(*ast.Ident); !ok || x.Name != e.i18nPackageName {
+        return
+    }
+    id, ok := extractStringLiteral(call.Args[0])
+    if !ok || id == "" {
+        return
+    }
+    text, ok := extractStringLiteral(call.Args[1])
+    if !ok {
+        return
+    }
+    e.messages = append(e.messages, &i18n.Message{ID: id, Other: text})
+}
+
+// extractStructTags extracts the messages of the i18n tags of the struct fields that Localizer.LocalizeStruct localizes,
+// e.g. `i18n:"id=Unread,count=Count,default={{.Name}} has {{.Count}} unread messages"`.
+// Tags without a default text take their text from the field at runtime,
+// which is extracted from the string literals assigned to the field, see extractFieldTexts.
+// Tags with a count are extracted with the "one" and "other" forms, so that merge asks for all plural forms of a language.
+func (e *extractor) extractStructTags(st *ast.StructType) {
+    if st.Fields == nil {
+        return
+    }
+    for _, field := range st.Fields.List {
+        if field.Tag == nil {
+            continue
+        }
+        tag, err := strconv.Unquote(field.Tag.Value)
+        if err != nil {
+            continue
+        }
+        value, ok := reflect.StructTag(tag).Lookup(structtag.Key)
+        if !ok || value == "-" {
+            continue
+        }
+        t, err := structtag.Parse(value)
+        if err != nil {
+            // LocalizeStruct fails on the field as well.
+            continue
+        }
+        m := &i18n.Message{ID: t.ID, Other: t.Default}
+        if t.Default == "" {
+            for _, name := range field.Names {
+                e.fieldMessages[m] = &structField{key: e.structName(st) + "." + name.Name, plural: t.Count != ""}
+            }
+        }
+        if t.Count != "" {
+            m.One = m.Other
+        }
+        e.messages = append(e.messages, m)
+    }
+}
+
+// structName returns the name of the struct type st, or "struct" if it is anonymous.
+func (e *extractor) structName(st *ast.StructType) string {
+    if name, ok := e.structNames[st]; ok {
+        return name
+    }
+    return "struct"
+}
+
+// extractFieldTexts records the string literals assigned to the fields of cl, a composite literal of the type typeName.
+func (e *extractor) extractFieldTexts(typeName string, cl *ast.CompositeLit) {
+    for _, el := range cl.Elts {
+        kve, ok := el.(*ast.KeyValueExpr)
+        if !ok {
+            continue
+        }
+        key, ok := kve.Key.(*ast.Ident)
+        if !ok {
+            continue
+        }
+        if text, ok := extractStringLiteral(kve.Value); ok {
+            k := typeName + "." + key.Name
+            e.fieldTexts[k] = append(e.fieldTexts[k], text)
+        }
+    }
+}
+
+// extractElidedFieldTexts records the string literals assigned to the fields of the composite literals in values
+// whose type is elided, as in []Inbox{{Title: "..."}}, with typ being the element type.
+func (e *extractor) extractElidedFieldTexts(typ ast.Expr, values []ast.Expr) {
+    if star, ok := typ.(*ast.StarExpr); ok {
+        typ = star.X
+    }
+    var typeName string
+    switch t := typ.(type) {
+    case *ast.Ident:
+        typeName = t.Name
+    case *ast.SelectorExpr:
+        typeName = t.Sel.Name
+    default:
+        return
+    }
+    for _, value := range values {
+        if u, ok := value.(*ast.UnaryExpr); ok {
+            value = u.X
+        }
+        if cl, ok := value.(*ast.CompositeLit); ok && cl.Type == nil {
+            e.extractFieldTexts(typeName, cl)
+        }
+    }
+}
+
 func (e *extractor) isMessageType(expr ast.Expr) bool {
     se := unwrapSelectorExpr(expr)
     if se == nil {
@@ -206,7 +465,7 @@ func unwrapSelectorExpr(e ast.Expr) *ast.SelectorExpr {
 }
 
 func (e *extractor) extractMessage(cl *ast.CompositeLit) {
-    data := make(map[string]string)
+    data := make(map[string]interface{})
     for _, elt := range cl.Elts {
         kve, ok := elt.(*ast.KeyValueExpr)
         if !ok {
@@ -216,7 +475,7 @@ func (e *extractor) extractMessage(cl *ast.CompositeLit) {
         if !ok {
             continue
         }
-        v, ok := extractStringLiteral(kve.Value)
+        v, ok := extractValue(kve.Value)
         if !ok {
             continue
         }
@@ -225,12 +484,63 @@ func (e *extractor) extractMessage(cl *ast.CompositeLit) {
     if len(data) == 0 {
         return
     }
-    if messageID := data["MessageID"]; messageID != "" {
+    if messageID, _ := data["MessageID"].(string); messageID != "" {
         data["ID"] = messageID
     }
     e.messages = append(e.messages, i18n.MustNewMessage(data))
 }
 
+// extractValue extracts the value of a field of a message:
+// a string, an integer like MaxLength, a []string like Tags or a map[string]string like Placeholders.
+func extractValue(expr ast.Expr) (interface{}, bool) {
+    if s, ok := extractStringLiteral(expr); ok {
+        return s, true
+    }
+    switch v := expr.(type) {
+    case *ast.BasicLit:
+        if v.Kind != token.INT {
+            return nil, false
+        }
+        i, err := strconv.ParseInt(v.Value, 0, 64)
+        if err != nil {
+            return nil, false
+        }
+        return i, true
+    case *ast.CompositeLit:
+        switch v.Type.(type) {
+        case *ast.ArrayType:
+            items := make([]string, 0, len(v.Elts))
+            for _, elt := range v.Elts {
+                item, ok := extractStringLiteral(elt)
+                if !ok {
+                    return nil, false
+                }
+                items = append(items, item)
+            }
+            return items, true
+        case *ast.MapType:
+            m := make(map[string]string, len(v.Elts))
+            for _, elt := range v.Elts {
+                kve, ok := elt.(*ast.KeyValueExpr)
+                if !ok {
+                    return nil, false
+                }
+                key, ok := extractStringLiteral(kve.Key)
+                if !ok {
+                    return nil, false
+                }
+                value, ok := extractStringLiteral(kve.Value)
+                if !ok {
+                    return nil, false
+                }
+                m[key] = value
+            }
+            return m, true
+        }
+    }
+    return nil, false
+}
+
 func extractStringLiteral(expr ast.Expr) (string, bool) {
     switch v := expr.(type) {
     case *ast.BasicLit:
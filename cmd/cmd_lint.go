@@ -0,0 +1,158 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+func usageLint() {
+	fmt.Fprintf(os.Stderr, `检查消息文件:
+
+    检查各语言的翻译是否超过消息的最大长度(maxlength,不含模板动作),以及是否原样保留了不可翻译的词语(donottranslate),
+    翻译中的maxlength优先于源消息的maxlength,发现问题时以非零状态退出
+
+Usage: i18n_cli lint [Option]... [MessageFile]...
+
+Option:
+    -source
+      源语言, 如: en(默认),en-US,zh-Hant-CN
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,未指定MessageFile时检查配置中messages的文件
+
+Example:
+    i18n_cli lint active.*.toml translate.*.toml
+
+`)
+}
+
+type lintCommand struct {
+	msgFiles []string
+	source   languageTag
+	cfg      *config
+}
+
+func (lc *lintCommand) name() string {
+	return "lint"
+}
+
+func (lc *lintCommand) parse(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	flags.Usage = usageLint
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	lc.cfg = cfg
+	lc.source = cfg.source(language.English)
+	flags.Var(&lc.source, "source", "en")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	lc.msgFiles = flags.Args()
+	return nil
+}
+
+func (lc *lintCommand) execute() error {
+	if len(lc.msgFiles) < 1 {
+		files, err := lc.cfg.messageFiles()
+		if err != nil {
+			return err
+		}
+		lc.msgFiles = files
+	}
+	if len(lc.msgFiles) < 1 {
+		usageLint()
+		return nil
+	}
+
+	sourceTag := lc.source.Tag()
+	files := make([]*i18n.MessageFile, 0, len(lc.msgFiles))
+	sourceMessageTemplates := map[string]*i18n.MessageTemplate{}
+	for _, path := range lc.msgFiles {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+		if err != nil {
+			return fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		if mf.Tag == sourceTag {
+			for _, m := range mf.Messages {
+				if template := i18n.NewMessageTemplate(m); template != nil {
+					sourceMessageTemplates[m.ID] = template
+				}
+			}
+		}
+		files = append(files, mf)
+	}
+
+	var problems []string
+	for _, mf := range files {
+		messageTemplates := make(map[string]*i18n.MessageTemplate, len(mf.Messages))
+		for _, m := range mf.Messages {
+			if template := i18n.NewMessageTemplate(m); template != nil {
+				messageTemplates[m.ID] = template
+			}
+		}
+		for _, id := range sortedIDs(messageTemplates) {
+			problems = append(problems, lintMessage(mf.Path, messageTemplates[id], sourceMessageTemplates[id], mf.Tag == sourceTag)...)
+		}
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems found", len(problems))
+	}
+	return nil
+}
+
+// lintMessage returns the problems of the message template in the file at path, with src being its source message if any.
+// Untranslated copies of the source are only checked in the source language.
+func lintMessage(path string, template, src *i18n.MessageTemplate, sourceLanguage bool) []string {
+	maxLength, doNotTranslate := template.MaxLength, template.DoNotTranslate
+	if src != nil {
+		if maxLength == 0 {
+			maxLength = src.MaxLength
+		}
+		if len(doNotTranslate) == 0 {
+			doNotTranslate = src.DoNotTranslate
+		}
+	}
+	var problems []string
+	for _, pluralForm := range pluralForms {
+		t := template.PluralTemplates[pluralForm]
+		if t == nil || t.Src == "" || (!sourceLanguage && src != nil && isSourceCopy(src, t)) {
+			continue
+		}
+		if length := textLength(t.Src, t.LeftDelim, t.RightDelim); maxLength > 0 && length > maxLength {
+			problems = append(problems, fmt.Sprintf("%s: %q (%s) is %d characters long, longer than the max length %d", path, template.ID, pluralForm, length, maxLength))
+		}
+		if sourceLanguage || src == nil {
+			continue
+		}
+		source := placeholder(src, pluralForm).Src
+		for _, term := range doNotTranslate {
+			if strings.Contains(source, term) && !strings.Contains(t.Src, term) {
+				problems = append(problems, fmt.Sprintf("%s: %q (%s) must keep %q untranslated", path, template.ID, pluralForm, term))
+			}
+		}
+	}
+	return problems
+}
+
+// textLength returns the number of characters of the template text src without its actions.
+func textLength(src, leftDelim, rightDelim string) int {
+	return utf8.RuneCountInString(templateActions(leftDelim, rightDelim).ReplaceAllString(src, ""))
+}
@@ -2,26 +2,31 @@ package main
 
 import (
 	"crypto/sha1"
-	"encoding/json"
+	"crypto/sha256"
 	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
-	"github.com/BurntSushi/toml"
 	"github.com/hollson/i18n"
 	"github.com/hollson/i18n/internal"
 	"github.com/hollson/i18n/internal/plural"
 
 	"golang.org/x/text/language"
-	yaml "gopkg.in/yaml.v2"
 )
 
 func usageMerge() {
 	fmt.Fprintf(os.Stderr, `合并消息文件:
 
     合并多语言消息文件,文件名必须具有受支持格式的后缀(例如“ .json”),并包含RFC 5646定义的有效语言标签(例如“ en-us”,“ fr”,“ zh-hant”等)
+    源消息变化后,已有的翻译会标记为fuzzy写入translate文件,等待译者审核,翻译记忆中有翻译时的源消息时一并写入(source)
+    Android和iOS文件同样按此规则命名,如: active.zh.xml, active.zh.strings, active.zh.stringsdict
+    这些格式没有对应的字段,消息的hash和fuzzy标记以注释(如 <!-- hash: sha256-... -->, <!-- fuzzy -->)保存,
+    iOS文件中占位符对应的字段名以 /* args: Name */ 注释保存,请勿删除
 
 Usage: i18n_cli merge [Option]... <Param>...
 
@@ -31,7 +36,28 @@ Option:
     -out
       文件输出路径
     -format
-      输出消息的文件格式,仅支持: toml(默认), json, yaml
+      输出消息的文件格式,支持: toml(默认), json, yaml,
+      xml(Android strings.xml), strings(iOS Localizable.strings), stringsdict(iOS复数字典)
+    -nested
+      按消息ID中的命名空间(如 errors.db.timeout)输出嵌套结构,而非扁平的键
+    -separator
+      嵌套输出时消息ID的命名空间分隔符,默认为".",使用其他分隔符时消息ID中不能包含"."
+    -memory
+      翻译记忆文件(JSON),以源文本的哈希为键保存已有的翻译,合并后会写入新的翻译
+    -glossary
+      术语表文件(toml, yaml, json),翻译未按术语表翻译指定术语或使用了禁用译法时输出警告
+    -dry-run
+      不修改文件,以unified diff格式输出将要进行的修改
+    -check
+      不修改文件,如果执行命令会修改任何文件则以非零状态退出(用于CI检查消息文件是否最新)
+
+    同一消息有多个翻译时,translate文件优先于其他文件,同类文件中命令行靠后的文件优先;
+    与源消息不同的翻译优先于源消息的副本,多个不同的翻译冲突时会列出所有候选翻译
+    待翻译的消息会从翻译记忆(已有的翻译及-memory文件)中查找源文本相同或相近(编辑距离)的翻译预填,
+    预填的建议标记为fuzzy并附带其源文本(source),等待译者审核
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,命令行参数优先于配置
 
 Example: 
     i18n_cli merge active.en.toml active.zh.toml
@@ -40,10 +66,17 @@ Example:
 }
 
 type mergeCommand struct {
-	msgFiles []string
-	source   languageTag
-	out      string
-	format   string
+	msgFiles  []string
+	source    languageTag
+	out       string
+	format    string
+	nested    bool
+	separator string
+	memory    string
+	glossary  string
+	dryRun    bool
+	check     bool
+	cfg       *config
 }
 
 func (mc *mergeCommand) name() string {
@@ -54,9 +87,21 @@ func (mc *mergeCommand) parse(args []string) error {
 	flags := flag.NewFlagSet("merge", flag.ExitOnError)
 	flags.Usage = usageMerge
 
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	mc.cfg = cfg
+	mc.source = cfg.source(language.English)
 	flags.Var(&mc.source, "source", "en")
-	flags.StringVar(&mc.out, "out", ".", "")
-	flags.StringVar(&mc.format, "format", "toml", "")
+	flags.StringVar(&mc.out, "out", cfg.out("."), "")
+	flags.StringVar(&mc.format, "format", cfg.format("toml"), "")
+	flags.BoolVar(&mc.nested, "nested", cfg.Nested, "")
+	flags.StringVar(&mc.separator, "separator", cfg.separator(defaultNestedSeparator), "")
+	flags.StringVar(&mc.memory, "memory", cfg.file(cfg.Memory, ""), "")
+	flags.StringVar(&mc.glossary, "glossary", cfg.file(cfg.Glossary, ""), "")
+	flags.BoolVar(&mc.dryRun, "dry-run", false, "")
+	flags.BoolVar(&mc.check, "check", false, "")
 	if err := flags.Parse(args); err != nil {
 		return err
 	}
@@ -65,52 +110,164 @@ func (mc *mergeCommand) parse(args []string) error {
 	return nil
 }
 
+// nestedSeparator returns the separator of nested message ids, or "" to write message ids as flat keys.
+func (mc *mergeCommand) nestedSeparator() string {
+	if !mc.nested {
+		return ""
+	}
+	return mc.separator
+}
+
 func (mc *mergeCommand) execute() error {
+	if len(mc.msgFiles) < 1 {
+		files, err := mc.cfg.messageFiles()
+		if err != nil {
+			return err
+		}
+		mc.msgFiles = files
+	}
 	if len(mc.msgFiles) < 1 {
 		usageMerge()
 		return nil
 	}
-	inFiles := make(map[string][]byte)
+	inFiles := make([]msgFile, 0, len(mc.msgFiles))
 	for _, path := range mc.msgFiles {
 		content, err := ioutil.ReadFile(path)
 		if err != nil {
 			return err
 		}
-		inFiles[path] = content
+		inFiles = append(inFiles, msgFile{path: path, content: content})
 	}
-	ops, err := merge(inFiles, mc.source.Tag(), mc.out, mc.format)
-	if err != nil {
-		return err
+	var memory translationMemory
+	if mc.memory != "" {
+		content, _, err := readIfExists(mc.memory)
+		if err != nil {
+			return err
+		}
+		if memory, err = parseMemory(content); err != nil {
+			return fmt.Errorf("failed to load translation memory %s: %s", mc.memory, err)
+		}
 	}
-	for path, content := range ops.writeFiles {
-		if err := ioutil.WriteFile(path, content, 0666); err != nil {
+	var g *glossary
+	if mc.glossary != "" {
+		content, err := ioutil.ReadFile(mc.glossary)
+		if err != nil {
 			return err
 		}
+		if g, err = parseGlossary(content, mc.glossary); err != nil {
+			return fmt.Errorf("failed to load glossary %s: %s", mc.glossary, err)
+		}
 	}
-	for _, path := range ops.deleteFiles {
-		// Ignore error since it isn't guaranteed to exist.
-		os.Remove(path)
+	ops, conflicts, violations, err := merge(inFiles, &mergeOptions{
+		sourceLanguageTag:  mc.source.Tag(),
+		targetLanguageTags: mc.cfg.languages(),
+		out:                mc.out,
+		outputFormat:       mc.format,
+		nestedSeparator:    mc.nestedSeparator(),
+		templateFuncs:      mc.cfg.TemplateFuncs,
+		memory:             memory,
+		memoryPath:         mc.memory,
+		glossary:           g,
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	for _, c := range conflicts {
+		fmt.Fprintln(os.Stderr, c)
+	}
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	return ops.run(mc.dryRun, mc.check)
 }
 
-type fileSystemOp struct {
-	writeFiles  map[string][]byte
-	deleteFiles []string
+// msgFile is a message file given to merge.
+type msgFile struct {
+	path    string
+	content []byte
 }
 
-func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outputFormat string) (*fileSystemOp, error) {
-	unmerged := make(map[language.Tag][]map[string]*i18n.MessageTemplate)
-	sourceMessageTemplates := make(map[string]*i18n.MessageTemplate)
-	unmarshalFuncs := map[string]i18n.UnmarshalFunc{
-		"json": json.Unmarshal,
-		"toml": toml.Unmarshal,
-		"yaml": yaml.Unmarshal,
+// unmergedFile holds the message templates of a message file that is not in the source language.
+type unmergedFile struct {
+	path      string
+	templates map[string]*i18n.MessageTemplate
+}
+
+// precedes tells whether the translations of file i take precedence over those of file j,
+// where i and j are the positions of the files on the command line.
+// Translate files take precedence over other files, since they hold the latest work of translators,
+// and later files take precedence over earlier files of the same kind.
+func precedes(pathI string, i int, pathJ string, j int) bool {
+	if ti, tj := isTranslateFile(pathI), isTranslateFile(pathJ); ti != tj {
+		return ti
 	}
-	for path, content := range msgFiles {
+	return i > j
+}
+
+func isTranslateFile(path string) bool {
+	return strings.HasPrefix(filepath.Base(path), "translate.")
+}
+
+// conflict lists the competing translations of a plural form of a message.
+type conflict struct {
+	langTag language.Tag
+	id      string
+	form    plural.Form
+	// candidates are the translations in order of precedence, the first one is used.
+	candidates []*candidate
+}
+
+// candidate is a translation found in a message file.
+type candidate struct {
+	path     string
+	template *internal.Template
+}
+
+func (c *conflict) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "conflicting %s translations of %q (%s), using %s:", c.langTag, c.id, c.form, c.candidates[0].path)
+	for _, candidate := range c.candidates {
+		fmt.Fprintf(&b, "\n    %s: %q", candidate.path, candidate.template.Src)
+	}
+	return b.String()
+}
+
+// mergeOptions are the options of merge.
+type mergeOptions struct {
+	sourceLanguageTag language.Tag
+	// targetLanguageTags are the languages to translate to, even if they have no message files yet.
+	targetLanguageTags []language.Tag
+	out                string
+	outputFormat       string
+	nestedSeparator    string
+	// templateFuncs are the names of the custom functions of message templates.
+	templateFuncs []string
+	// memory is the translation memory of earlier merges, which is updated with the active translations.
+	// It is written to memoryPath unless that is empty.
+	memory     translationMemory
+	memoryPath string
+	// glossary is checked by the translations if it is not nil.
+	glossary *glossary
+}
+
+// merge merges the translations of msgFiles into the active and translate files of each language.
+// Competing translations of the same message are resolved by precedence and returned as conflicts.
+// Messages left to translate are prefilled with suggestions of the translation memory,
+// and translations that do not follow the glossary are returned as violations.
+func merge(msgFiles []msgFile, opts *mergeOptions) (*fileSystemOp, []*conflict, []*glossaryViolation, error) {
+	sourceLanguageTag, out, outputFormat, nestedSeparator := opts.sourceLanguageTag, opts.out, opts.outputFormat, opts.nestedSeparator
+	unmerged := make(map[language.Tag][]*unmergedFile)
+	positions := make(map[string]int, len(msgFiles))
+	sourceMessageTemplates := make(map[string]*i18n.MessageTemplate)
+	for i, f := range msgFiles {
+		path, content := f.path, f.content
+		positions[path] = i
 		mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load message file %s: %s", path, err)
+			return nil, nil, nil, fmt.Errorf("failed to load message file %s: %s", path, err)
+		}
+		if err := splitNestedIDs(mf, content, nestedSeparator); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load message file %s: %s", path, err)
 		}
 		templates := map[string]*i18n.MessageTemplate{}
 		for _, m := range mf.Messages {
@@ -120,27 +277,46 @@ func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outp
 			}
 			templates[m.ID] = template
 		}
+		for _, problem := range checkTemplates(templates, opts.templateFuncs) {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", path, problem)
+		}
 		if mf.Tag == sourceLanguageTag {
 			for _, template := range templates {
 				if sourceMessageTemplates[template.ID] != nil {
-					return nil, fmt.Errorf("multiple source translations for id %q", template.ID)
+					return nil, nil, nil, fmt.Errorf("multiple source translations for id %q", template.ID)
 				}
 				template.Hash = hash(template)
 				sourceMessageTemplates[template.ID] = template
 			}
 		}
-		unmerged[mf.Tag] = append(unmerged[mf.Tag], templates)
+		unmerged[mf.Tag] = append(unmerged[mf.Tag], &unmergedFile{path: path, templates: templates})
 	}
 
 	if len(sourceMessageTemplates) == 0 {
-		return nil, fmt.Errorf("no messages found for source locale %s", sourceLanguageTag)
+		return nil, nil, nil, fmt.Errorf("no messages found for source locale %s", sourceLanguageTag)
+	}
+
+	for _, tag := range opts.targetLanguageTags {
+		if _, ok := unmerged[tag]; !ok && tag != sourceLanguageTag {
+			// A new language to translate to.
+			unmerged[tag] = nil
+		}
+	}
+
+	// Sort the files of each language by precedence.
+	for _, files := range unmerged {
+		sort.SliceStable(files, func(i, j int) bool {
+			return precedes(files[i].path, positions[files[i].path], files[j].path, positions[files[j].path])
+		})
 	}
 
 	pluralRules := plural.DefaultRules()
 	all := make(map[language.Tag]map[string]*i18n.MessageTemplate)
+	stale := make(map[language.Tag]map[string]*i18n.MessageTemplate)
+	var conflicts []*conflict
 	all[sourceLanguageTag] = sourceMessageTemplates
 	for _, srcTemplate := range sourceMessageTemplates {
-		for dstLangTag, messageTemplates := range unmerged {
+		for dstLangTag, files := range unmerged {
 			if dstLangTag == sourceLanguageTag {
 				continue
 			}
@@ -155,39 +331,83 @@ func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outp
 			}
 			dstMessageTemplate := all[dstLangTag][srcTemplate.ID]
 			if dstMessageTemplate == nil {
-				dstMessageTemplate = &i18n.MessageTemplate{
-					Message: &i18n.Message{
-						ID:   srcTemplate.ID,
-						Desc: srcTemplate.Desc,
-						Hash: srcTemplate.Hash,
-					},
-					PluralTemplates: make(map[plural.Form]*internal.Template),
-				}
+				dstMessageTemplate = newDst(srcTemplate)
 				all[dstLangTag][srcTemplate.ID] = dstMessageTemplate
 			}
 
 			// Check all unmerged message templates for this message id.
-			for _, messageTemplates := range messageTemplates {
-				unmergedTemplate := messageTemplates[srcTemplate.ID]
+			var translations []*unmergedFile
+			for _, f := range files {
+				unmergedTemplate := f.templates[srcTemplate.ID]
 				if unmergedTemplate == nil {
 					continue
 				}
-				// Ignore empty hashes for v1 backward compatibility.
-				if unmergedTemplate.Hash != "" && unmergedTemplate.Hash != srcTemplate.Hash {
-					// This was translated from different content so discard.
+				if unmergedTemplate.Fuzzy || unmergedTemplate.MachineTranslated || !hashMatches(unmergedTemplate.Hash, srcTemplate) {
+					// This was translated from different content or by a machine, keep it for translators to review.
+					if stale[dstLangTag] == nil {
+						stale[dstLangTag] = make(map[string]*i18n.MessageTemplate)
+					}
+					if stale[dstLangTag][srcTemplate.ID] == nil {
+						stale[dstLangTag][srcTemplate.ID] = unmergedTemplate
+					}
 					continue
 				}
+				translations = append(translations, f)
+			}
 
-				// Merge in the translated messages.
-				for pluralForm := range pluralRule.PluralForms {
-					dt := unmergedTemplate.PluralTemplates[pluralForm]
-					if dt != nil && dt.Src != "" {
-						dstMessageTemplate.PluralTemplates[pluralForm] = dt
+			// The review status of the translation is that of the file with the highest precedence that has one.
+			for _, f := range translations {
+				if status := f.templates[srcTemplate.ID].Status; status != "" {
+					dstMessageTemplate.Status = status
+					break
+				}
+			}
+
+			// Merge in the translated messages.
+			for pluralForm := range pluralRule.PluralForms {
+				var candidates []*candidate
+				for _, f := range translations {
+					dt := f.templates[srcTemplate.ID].PluralTemplates[pluralForm]
+					if dt == nil || dt.Src == "" {
+						continue
+					}
+					if isTranslateFile(f.path) && isSourceCopy(srcTemplate, dt) {
+						// An untranslated placeholder written by merge.
+						continue
 					}
+					candidates = append(candidates, &candidate{path: f.path, template: dt})
+				}
+				if len(candidates) == 0 {
+					continue
+				}
+				candidates, competing := rankCandidates(srcTemplate, candidates)
+				dstMessageTemplate.PluralTemplates[pluralForm] = candidates[0].template
+				if competing {
+					conflicts = append(conflicts, &conflict{langTag: dstLangTag, id: srcTemplate.ID, form: pluralForm, candidates: candidates})
 				}
 			}
 		}
 	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		ci, cj := conflicts[i], conflicts[j]
+		if ci.langTag != cj.langTag {
+			return ci.langTag.String() < cj.langTag.String()
+		}
+		if ci.id != cj.id {
+			return ci.id < cj.id
+		}
+		return ci.form < cj.form
+	})
+
+	memory := opts.memory
+	if memory == nil {
+		memory = translationMemory{}
+	}
+	for langTag, messageTemplates := range all {
+		if langTag != sourceLanguageTag {
+			memory.addTranslations(langTag, sourceMessageTemplates, messageTemplates)
+		}
+	}
 
 	translate := make(map[language.Tag]map[string]*i18n.MessageTemplate)
 	active := make(map[language.Tag]map[string]*i18n.MessageTemplate)
@@ -206,6 +426,14 @@ func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outp
 		for _, messageTemplate := range messageTemplates {
 			srcMessageTemplate := sourceMessageTemplates[messageTemplate.ID]
 			activeMessageTemplate, translateMessageTemplate := activeDst(srcMessageTemplate, messageTemplate, pluralRule)
+			if staleMessageTemplate := stale[langTag][messageTemplate.ID]; staleMessageTemplate != nil && activeMessageTemplate == nil {
+				translateMessageTemplate = fuzzyDst(srcMessageTemplate, staleMessageTemplate, pluralRule)
+				if len(translateMessageTemplate.Source) == 0 {
+					translateMessageTemplate.Source = memory.sources(langTag, staleMessageTemplate)
+				}
+			} else if translateMessageTemplate != nil {
+				memory.suggestTranslations(langTag, srcMessageTemplate, translateMessageTemplate)
+			}
 			if translateMessageTemplate != nil {
 				if translate[langTag] == nil {
 					translate[langTag] = make(map[string]*i18n.MessageTemplate)
@@ -218,19 +446,55 @@ func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outp
 		}
 	}
 
+	var violations []*glossaryViolation
+	if opts.glossary != nil {
+		for langTag := range all {
+			if langTag == sourceLanguageTag {
+				continue
+			}
+			violations = append(violations, opts.glossary.check(langTag, sourceMessageTemplates, active[langTag])...)
+			violations = append(violations, opts.glossary.check(langTag, sourceMessageTemplates, translate[langTag])...)
+		}
+		sort.SliceStable(violations, func(i, j int) bool {
+			vi, vj := violations[i], violations[j]
+			if vi.langTag != vj.langTag {
+				return vi.langTag.String() < vj.langTag.String()
+			}
+			return vi.id < vj.id
+		})
+	}
+
+	// Keep the key order and comments of the files that are rewritten.
+	existing := make(map[string][]byte, len(msgFiles))
+	for _, f := range msgFiles {
+		existing[filepath.Clean(f.path)] = f.content
+	}
 	writeFiles := make(map[string][]byte, len(translate)+len(active))
 	for langTag, messageTemplates := range translate {
-		path, content, err := writeFile(out, "translate", langTag, outputFormat, messageTemplates, false)
+		path, content, err := writeFile(out, "translate", langTag, outputFormat, messageTemplates, false, nestedSeparator, existing)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 		writeFiles[path] = content
 	}
 	deleteFiles := []string{}
+	for langTag, files := range unmerged {
+		if langTag == sourceLanguageTag || all[langTag] == nil {
+			continue
+		}
+		for _, f := range files {
+			// The translations of a translate file are merged and what is left to translate is rewritten,
+			// so a translate file that is not rewritten is done and would otherwise outrank later edits of active files.
+			if path := filepath.Clean(f.path); isTranslateFile(path) && writeFiles[path] == nil {
+				deleteFiles = append(deleteFiles, path)
+			}
+		}
+	}
+	sort.Strings(deleteFiles)
 	for langTag, messageTemplates := range active {
-		path, content, err := writeFile(out, "active", langTag, outputFormat, messageTemplates, langTag == sourceLanguageTag)
+		path, content, err := writeFile(out, "active", langTag, outputFormat, messageTemplates, langTag == sourceLanguageTag, nestedSeparator, existing)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 		if len(content) > 0 {
 			writeFiles[path] = content
@@ -238,51 +502,157 @@ func merge(msgFiles map[string][]byte, sourceLanguageTag language.Tag, out, outp
 			deleteFiles = append(deleteFiles, path)
 		}
 	}
-	return &fileSystemOp{writeFiles: writeFiles, deleteFiles: deleteFiles}, nil
+	if opts.memoryPath != "" {
+		content, err := memory.marshal()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		writeFiles[opts.memoryPath] = content
+	}
+	return &fileSystemOp{writeFiles: writeFiles, deleteFiles: deleteFiles}, conflicts, violations, nil
 }
 
-// activeDst returns the active part of the dst and whether dst is a complete translation of src.
-func activeDst(src, dst *i18n.MessageTemplate, pluralRule *plural.Rule) (active *i18n.MessageTemplate, translateMessageTemplate *i18n.MessageTemplate) {
-	pluralForms := pluralRule.PluralForms
-	if len(src.PluralTemplates) == 1 {
-		pluralForms = map[plural.Form]struct{}{
-			plural.Other: {},
+// rankCandidates returns the candidate translations of a plural form of src in the order they are used.
+// Like a three-way merge with src as the base, translations that differ from the source text
+// take precedence over copies of it, which merge writes to translate files as placeholders.
+// It also tells whether several different translations compete.
+func rankCandidates(src *i18n.MessageTemplate, candidates []*candidate) ([]*candidate, bool) {
+	ranked := make([]*candidate, 0, len(candidates))
+	values := map[string]bool{}
+	for _, c := range candidates {
+		if !isSourceCopy(src, c.template) {
+			ranked = append(ranked, c)
+			values[c.template.Src] = true
+		}
+	}
+	for _, c := range candidates {
+		if isSourceCopy(src, c.template) {
+			ranked = append(ranked, c)
+		}
+	}
+	return ranked, len(values) > 1
+}
+
+// isSourceCopy tells whether the translation t is a copy of a plural form of src.
+func isSourceCopy(src *i18n.MessageTemplate, t *internal.Template) bool {
+	for _, template := range src.PluralTemplates {
+		if t.Src == template.Src {
+			return true
 		}
 	}
-	for pluralForm := range pluralForms {
+	return false
+}
+
+// activeDst returns the active part of the dst and whether dst is a complete translation of src.
+func activeDst(src, dst *i18n.MessageTemplate, pluralRule *plural.Rule) (active *i18n.MessageTemplate, translateMessageTemplate *i18n.MessageTemplate) {
+	for pluralForm := range requiredForms(src, pluralRule) {
 		dt := dst.PluralTemplates[pluralForm]
 		if dt == nil || dt.Src == "" {
 			if translateMessageTemplate == nil {
-				translateMessageTemplate = &i18n.MessageTemplate{
-					Message: &i18n.Message{
-						ID:   src.ID,
-						Desc: src.Desc,
-						Hash: src.Hash,
-					},
-					PluralTemplates: make(map[plural.Form]*internal.Template),
-				}
+				translateMessageTemplate = newDst(src)
 			}
 			translateMessageTemplate.PluralTemplates[pluralForm] = src.PluralTemplates[plural.Other]
 			continue
 		}
 		if active == nil {
-			active = &i18n.MessageTemplate{
-				Message: &i18n.Message{
-					ID:   src.ID,
-					Desc: src.Desc,
-					Hash: src.Hash,
-				},
-				PluralTemplates: make(map[plural.Form]*internal.Template),
-			}
+			active = newDst(src)
+			active.Status = dst.Status
 		}
 		active.PluralTemplates[pluralForm] = dt
 	}
 	return
 }
 
+// fuzzyDst returns the translation dst of src that translators need to review:
+// stale translations are marked as fuzzy, with the source they were translated from if dst recorded it,
+// and machine translations stay marked as such.
+// Plural forms that dst lacks are filled with the source like untranslated messages.
+func fuzzyDst(src, dst *i18n.MessageTemplate, pluralRule *plural.Rule) *i18n.MessageTemplate {
+	fuzzy := newDst(src)
+	fuzzy.MachineTranslated = dst.MachineTranslated
+	fuzzy.Fuzzy = dst.Fuzzy || !dst.MachineTranslated || !hashMatches(dst.Hash, src)
+	fuzzy.Source = dst.Source
+	for pluralForm := range requiredForms(src, pluralRule) {
+		if dt := dst.PluralTemplates[pluralForm]; dt != nil && dt.Src != "" {
+			fuzzy.PluralTemplates[pluralForm] = dt
+		} else {
+			fuzzy.PluralTemplates[pluralForm] = src.PluralTemplates[plural.Other]
+		}
+	}
+	return fuzzy
+}
+
+// requiredForms returns the plural forms a translation of src needs.
+func requiredForms(src *i18n.MessageTemplate, pluralRule *plural.Rule) map[plural.Form]struct{} {
+	if len(src.PluralTemplates) == 1 {
+		return map[plural.Form]struct{}{
+			plural.Other: {},
+		}
+	}
+	return pluralRule.PluralForms
+}
+
+// newDst returns an empty translation of src with the metadata of src for translators.
+func newDst(src *i18n.MessageTemplate) *i18n.MessageTemplate {
+	return &i18n.MessageTemplate{
+		Message: &i18n.Message{
+			ID:             src.ID,
+			Desc:           src.Desc,
+			Context:        src.Context,
+			Hash:           src.Hash,
+			LeftDelim:      src.LeftDelim,
+			RightDelim:     src.RightDelim,
+			MaxLength:      src.MaxLength,
+			Reference:      src.Reference,
+			Tags:           src.Tags,
+			DoNotTranslate: src.DoNotTranslate,
+			Placeholders:   src.Placeholders,
+		},
+		PluralTemplates: make(map[plural.Form]*internal.Template),
+	}
+}
+
+// hash identifies the content of a source message that translations depend on:
+// its description, template delimiters, all of its plural forms and its context.
 func hash(t *i18n.MessageTemplate) string {
+	h := sha256.New()
+	fields := []string{t.Desc, t.LeftDelim, t.RightDelim}
+	for _, pluralForm := range pluralForms {
+		if template := t.PluralTemplates[pluralForm]; template != nil {
+			fields = append(fields, string(pluralForm), template.Src)
+		}
+	}
+	if t.Context != "" {
+		// Only messages with a context hash it, so the hashes of other messages stay the same.
+		fields = append(fields, "context", t.Context)
+	}
+	for _, field := range fields {
+		// Prefix fields with their length so that moving text between fields changes the hash.
+		_, _ = fmt.Fprintf(h, "%d:%s", len(field), field)
+	}
+	return fmt.Sprintf("sha256-%x", h.Sum(nil))
+}
+
+// legacyHash is the hash of older versions, which only covered the description and the "other" form.
+func legacyHash(t *i18n.MessageTemplate) string {
 	h := sha1.New()
 	_, _ = io.WriteString(h, t.Desc)
-	_, _ = io.WriteString(h, t.PluralTemplates[plural.Other].Src)
+	if other := t.PluralTemplates[plural.Other]; other != nil {
+		_, _ = io.WriteString(h, other.Src)
+	}
 	return fmt.Sprintf("sha1-%x", h.Sum(nil))
 }
+
+// hashMatches tells whether a translation with hash h was translated from the current content of src.
+// Empty hashes are accepted for v1 backward compatibility,
+// legacy hashes as long as the description and the "other" form of src are unchanged
+// and src has no other plural forms, which legacy hashes did not cover.
+func hashMatches(h string, src *i18n.MessageTemplate) bool {
+	switch {
+	case h == "" || h == src.Hash:
+		return true
+	case strings.HasPrefix(h, "sha1-"):
+		return len(src.PluralTemplates) == 1 && src.PluralTemplates[plural.Other] != nil && h == legacyHash(src)
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/i18nmt"
+	"github.com/hollson/i18n/internal"
+	"github.com/hollson/i18n/internal/plural"
+	"golang.org/x/text/language"
+)
+
+func usageTranslate() {
+	fmt.Fprintf(os.Stderr, `机器翻译:
+
+    调用翻译服务预填translate文件中未翻译(各复数形式均为空或与源语言active文件中的源文本相同)的消息,模板动作(如{{.Name}})替换为{0},{1}等占位符后再翻译,
+    译文标记为machine_translated = true,经译者审核并删除该标记后,merge才会将其合并到active文件
+
+Usage: i18n_cli translate [Option]... [TranslateFile]...
+
+Option:
+    -url
+      翻译服务地址(必填), 以JSON格式POST请求:
+      {"source":"en","target":"ru","id":"...","description":"...","context":"...","form":"few","text":"..."}
+      响应格式: {"text":"..."}
+    -timeout
+      每次请求的超时时间,默认为30s
+    -source
+      源语言, 如: en(默认),en-US,zh-Hant-CN
+    -out
+      未指定TranslateFile时,翻译该路径下所有的translate文件
+    -format
+      未指定TranslateFile时,translate文件的格式,支持: toml(默认), json, yaml
+    -dry-run
+      只以unified diff格式输出将要发生的改动,不写入文件
+
+Config:
+    从当前目录逐级向上查找.i18n.toml或.i18n.yaml项目配置文件,命令行参数优先于配置
+
+Example:
+    i18n_cli translate -url http://localhost:8080/translate
+    i18n_cli translate -url http://localhost:8080/translate translate.ru.toml
+
+`)
+}
+
+type translateCommand struct {
+	msgFiles []string
+	url      string
+	timeout  time.Duration
+	source   languageTag
+	out      string
+	format   string
+	dryRun   bool
+	cfg      *config
+}
+
+func (tc *translateCommand) name() string {
+	return "translate"
+}
+
+func (tc *translateCommand) parse(args []string) error {
+	flags := flag.NewFlagSet("translate", flag.ExitOnError)
+	flags.Usage = usageTranslate
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	tc.cfg = cfg
+	tc.source = cfg.source(language.English)
+	flags.StringVar(&tc.url, "url", "", "")
+	flags.DurationVar(&tc.timeout, "timeout", 30*time.Second, "")
+	flags.Var(&tc.source, "source", "en")
+	flags.StringVar(&tc.out, "out", cfg.out("."), "")
+	flags.StringVar(&tc.format, "format", cfg.format("toml"), "")
+	flags.BoolVar(&tc.dryRun, "dry-run", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	tc.msgFiles = flags.Args()
+	return nil
+}
+
+func (tc *translateCommand) execute() error {
+	if tc.url == "" {
+		usageTranslate()
+		return fmt.Errorf("missing -url of the translation service")
+	}
+	paths := tc.msgFiles
+	if len(paths) == 0 {
+		matches, err := filepath.Glob(filepath.Join(tc.out, "translate.*."+tc.format))
+		if err != nil {
+			return err
+		}
+		paths = matches
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no translate files found in %s, run merge first", tc.out)
+	}
+
+	// Translate files hold the ids of nested files joined by ".", so the ids of the source are not split either.
+	sourceMessageTemplates, err := readSourceMessageTemplates(tc.cfg, tc.source.Tag(), tc.out, tc.format, "")
+	if err != nil {
+		return err
+	}
+	t := i18nmt.NewHTTPTranslator(tc.url, nil)
+	op := &fileSystemOp{writeFiles: map[string][]byte{}}
+	failed := 0
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		outContent, translated, failures, err := tc.translateFile(t, sourceMessageTemplates, path, content)
+		if err != nil {
+			return err
+		}
+		failed += failures
+		if translated > 0 {
+			op.writeFiles[path] = outContent
+		}
+		fmt.Fprintf(os.Stderr, "%s: %d messages translated\n", path, translated)
+	}
+	if err := op.run(tc.dryRun, false); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d messages could not be translated", failed)
+	}
+	return nil
+}
+
+// translateFile translates the untranslated messages of the translate file at path with t,
+// those whose plural forms are all empty or copies of the source in sourceMessageTemplates.
+// It returns the new content of the file, the number of translated messages and the number of failures,
+// which are reported to stderr.
+func (tc *translateCommand) translateFile(t i18nmt.Translator, sourceMessageTemplates map[string]*i18n.MessageTemplate, path string, content []byte) ([]byte, int, int, error) {
+	mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to load message file %s: %s", path, err)
+	}
+	switch mf.Format {
+	case "json", "toml", "yaml":
+	default:
+		return nil, 0, 0, fmt.Errorf("%s: translating %s files is not supported", path, mf.Format)
+	}
+	messageTemplates := make(map[string]*i18n.MessageTemplate, len(mf.Messages))
+	for _, m := range mf.Messages {
+		if template := i18n.NewMessageTemplate(m); template != nil {
+			messageTemplates[m.ID] = template
+		}
+	}
+
+	ids := make([]string, 0, len(messageTemplates))
+	for id := range messageTemplates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	translated, failures := 0, 0
+	for _, id := range ids {
+		template := messageTemplates[id]
+		if template.Fuzzy || template.MachineTranslated {
+			// Stale and machine translations are waiting for review.
+			continue
+		}
+		src := sourceMessageTemplates[id]
+		if !isUntranslated(src, template) {
+			// Translators already worked on it, or it is no longer in the source.
+			continue
+		}
+		for form, pt := range template.PluralTemplates {
+			if other := src.PluralTemplates[plural.Other]; pt.Src == "" && other != nil {
+				template.PluralTemplates[form] = other
+			}
+		}
+		mt, err := tc.translateMessage(t, mf.Tag, template)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: failed to translate message %q: %s\n", path, id, err)
+			failures++
+			continue
+		}
+		messageTemplates[id] = mt
+		translated++
+	}
+	if translated == 0 {
+		return content, 0, failures, nil
+	}
+
+	v, err := layoutValue(mf, content, messageTemplates, mf.Format)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to write %s: %s", path, err)
+	}
+	outContent, err := marshal(v, mf.Format, content)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to write %s: %s", path, err)
+	}
+	return outContent, translated, failures, nil
+}
+
+// isUntranslated tells whether all plural forms of the translation template of src are empty or copies of src.
+func isUntranslated(src, template *i18n.MessageTemplate) bool {
+	if src == nil {
+		return false
+	}
+	for _, pt := range template.PluralTemplates {
+		if pt.Src != "" && !isSourceCopy(src, pt) {
+			return false
+		}
+	}
+	return true
+}
+
+// translateMessage returns the machine translation of all plural forms of the untranslated message template,
+// whose plural forms hold the source text merge put in place.
+func (tc *translateCommand) translateMessage(t i18nmt.Translator, target language.Tag, template *i18n.MessageTemplate) (*i18n.MessageTemplate, error) {
+	mt := &i18n.MessageTemplate{
+		Message:         &i18n.Message{},
+		PluralTemplates: make(map[plural.Form]*internal.Template, len(template.PluralTemplates)),
+	}
+	*mt.Message = *template.Message
+	mt.MachineTranslated = true
+	for _, form := range pluralForms {
+		pt := template.PluralTemplates[form]
+		if pt == nil {
+			continue
+		}
+		text, actions, err := protectActions(pt.Src, pt.LeftDelim, pt.RightDelim)
+		if err != nil {
+			return nil, fmt.Errorf("%s form: %s", form, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+		translation, err := t.Translate(ctx, &i18nmt.Request{
+			Source:      tc.source.Tag(),
+			Target:      target,
+			ID:          template.ID,
+			Description: template.Desc,
+			Context:     template.Context,
+			Form:        form,
+			Text:        text,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("%s form: %s", form, err)
+		}
+		src, err := restoreActions(translation, actions)
+		if err != nil {
+			return nil, fmt.Errorf("%s form: %s", form, err)
+		}
+		mt.PluralTemplates[form] = &internal.Template{Src: src, LeftDelim: pt.LeftDelim, RightDelim: pt.RightDelim}
+	}
+	return mt, nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/i18nmt"
+	"golang.org/x/text/language"
+)
+
+func TestProtectActions(t *testing.T) {
+	tests := []struct {
+		src, leftDelim, rightDelim string
+		text                       string
+		actions                    []string
+	}{
+		{"Hello", "", "", "Hello", nil},
+		{"Hello {{.Name}}", "", "", "Hello {0}", []string{"{{.Name}}"}},
+		{"{{.Name}} has {{.Count}} cats", "", "", "{0} has {1} cats", []string{"{{.Name}}", "{{.Count}}"}},
+		{"<<.Name>> and {{.Name}}", "<<", ">>", "{0} and {{.Name}}", []string{"<<.Name>>"}},
+	}
+	for _, test := range tests {
+		text, actions, err := protectActions(test.src, test.leftDelim, test.rightDelim)
+		if err != nil {
+			t.Errorf("protectActions(%q) returned error %s", test.src, err)
+			continue
+		}
+		if text != test.text || strings.Join(actions, "|") != strings.Join(test.actions, "|") {
+			t.Errorf("protectActions(%q) = %q, %q; expected %q, %q", test.src, text, actions, test.text, test.actions)
+		}
+	}
+	if _, _, err := protectActions("{0} {{.Name}}", "", ""); err == nil {
+		t.Errorf("protectActions accepted a text with a placeholder")
+	}
+}
+
+func TestRestoreActions(t *testing.T) {
+	actions := []string{"{{.Name}}", "{{.Count}}"}
+	tests := []struct {
+		text     string
+		restored string
+		ok       bool
+	}{
+		{"{1} кошек у {0}", "{{.Count}} кошек у {{.Name}}", true},
+		{"{0}", "", false},
+		{"{0} {1} {1}", "", false},
+		{"{0} {1} {2}", "", false},
+	}
+	for _, test := range tests {
+		restored, err := restoreActions(test.text, actions)
+		if (err == nil) != test.ok || restored != test.restored {
+			t.Errorf("restoreActions(%q) = %q, %v; expected %q, ok %v", test.text, restored, err, test.restored, test.ok)
+		}
+	}
+}
+
+func TestTranslateFile(t *testing.T) {
+	var requests []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+		translations := map[string]string{
+			"Hello {0}":        "Привет {0}",
+			"Bye":              "Пока",
+			"{0} cats":         "кошки",
+			"{0} cat":          "{0} кошка",
+			"Stale text":       "Устаревший текст",
+			"Добро пожаловать": "Welcome back",
+			"{0} собака":       "{0} dog",
+			"{0} dogs":         "{0} собак",
+		}
+		json.NewEncoder(w).Encode(map[string]string{"text": translations[req["text"]]})
+	}))
+	defer server.Close()
+
+	content := []byte(`[Hello]
+hash = "sha256-1"
+other = "Hello {{.Name}}"
+
+[Bye]
+hash = "sha256-2"
+description = "Farewell"
+other = "Bye"
+
+[Cats]
+hash = "sha256-3"
+one = "{{.Count}} cat"
+other = "{{.Count}} cats"
+
+[Stale]
+fuzzy = true
+hash = "sha256-4"
+other = "Stale text"
+
+[Welcome]
+hash = "sha256-5"
+other = "Добро пожаловать"
+
+[Dogs]
+hash = "sha256-6"
+one = "{{.Count}} собака"
+other = "{{.Count}} dogs"
+
+[Removed]
+hash = "sha256-7"
+other = "Removed"
+`)
+	sourceMessageTemplates := map[string]*i18n.MessageTemplate{}
+	for _, m := range []*i18n.Message{
+		{ID: "Hello", Other: "Hello {{.Name}}"},
+		{ID: "Bye", Desc: "Farewell", Other: "Bye"},
+		{ID: "Cats", One: "{{.Count}} cat", Other: "{{.Count}} cats"},
+		{ID: "Stale", Other: "New text"},
+		{ID: "Welcome", Other: "Welcome"},
+		{ID: "Dogs", One: "{{.Count}} dog", Other: "{{.Count}} dogs"},
+	} {
+		sourceMessageTemplates[m.ID] = i18n.NewMessageTemplate(m)
+	}
+	tc := &translateCommand{source: languageTag(language.English), timeout: time.Second}
+	out, translated, failures, err := tc.translateFile(i18nmt.NewHTTPTranslator(server.URL, nil), sourceMessageTemplates, "translate.ru.toml", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cats fails since the translation of its other form drops the placeholder.
+	if translated != 2 || failures != 1 {
+		t.Errorf("translated %d messages with %d failures; expected 2 with 1 failure", translated, failures)
+	}
+	for _, req := range requests {
+		if req["source"] != "en" || req["target"] != "ru" {
+			t.Errorf("request %v has the wrong languages", req)
+		}
+		switch req["id"] {
+		case "Stale":
+			t.Errorf("fuzzy message was sent to translation")
+		case "Welcome", "Dogs":
+			t.Errorf("message %s translated by hand was sent to translation", req["id"])
+		case "Removed":
+			t.Errorf("message that is no longer in the source was sent to translation")
+		}
+		if req["id"] == "Bye" && req["description"] != "Farewell" {
+			t.Errorf("request %v lacks the description", req)
+		}
+	}
+
+	mf, err := i18n.ParseMessageFileBytes(out, "translate.ru.toml", unmarshalFuncs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := map[string]*i18n.Message{}
+	for _, m := range mf.Messages {
+		messages[m.ID] = m
+	}
+	expected := map[string]struct {
+		other             string
+		machineTranslated bool
+	}{
+		"Hello":   {"Привет {{.Name}}", true},
+		"Bye":     {"Пока", true},
+		"Cats":    {"{{.Count}} cats", false},
+		"Stale":   {"Stale text", false},
+		"Welcome": {"Добро пожаловать", false},
+		"Dogs":    {"{{.Count}} dogs", false},
+		"Removed": {"Removed", false},
+	}
+	for id, e := range expected {
+		m := messages[id]
+		if m == nil {
+			t.Errorf("message %s is missing", id)
+			continue
+		}
+		if m.Other != e.other || m.MachineTranslated != e.machineTranslated {
+			t.Errorf("message %s is %q, machine translated %v; expected %q, %v", id, m.Other, m.MachineTranslated, e.other, e.machineTranslated)
+		}
+	}
+	if !messages["Stale"].Fuzzy {
+		t.Errorf("message Stale is no longer fuzzy")
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	gotemplate "text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v2"
+)
+
+// configFileNames are the names of project configuration files in order of preference.
+var configFileNames = []string{".i18n.toml", ".i18n.yaml", ".i18n.yml"}
+
+// config is the project configuration of i18n_cli, found by walking up from the working directory.
+// Paths and glob patterns are relative to the directory of the configuration file.
+// Flags given on the command line override the configuration.
+type config struct {
+	// Source is the language of the source messages.
+	Source string `toml:"source" yaml:"source"`
+
+	// Languages are the target languages. merge creates translate files for those that have no message files yet.
+	Languages []string `toml:"languages" yaml:"languages"`
+
+	// Messages are glob patterns of the message files merge reads if no files are given.
+	Messages []string `toml:"messages" yaml:"messages"`
+
+	// Paths are the Go source files and directories extract reads if none are given.
+	Paths []string `toml:"paths" yaml:"paths"`
+
+	// Ignore are glob patterns of paths that extract and merge skip.
+	// Patterns match paths relative to the configuration file or their base names.
+	Ignore []string `toml:"ignore" yaml:"ignore"`
+
+	// Out is the directory message files are written to.
+	Out string `toml:"out" yaml:"out"`
+
+	// Format is the format of the message files that are written.
+	Format string `toml:"format" yaml:"format"`
+
+	// Nested writes message ids as nested namespaces split by Separator.
+	Nested    bool   `toml:"nested" yaml:"nested"`
+	Separator string `toml:"separator" yaml:"separator"`
+
+	// Memory is the file merge keeps the translation memory in.
+	Memory string `toml:"memory" yaml:"memory"`
+
+	// Glossary is the file of terms merge checks the translations against.
+	Glossary string `toml:"glossary" yaml:"glossary"`
+
+	// TemplateFuncs are the names of the custom template functions messages may use,
+	// i.e. the keys of the LocalizeConfig.Funcs of the application.
+	TemplateFuncs []string `toml:"template_funcs" yaml:"template_funcs"`
+
+	// dir is the directory of the configuration file.
+	dir string
+}
+
+// loadConfig returns the configuration of the project the working directory belongs to,
+// or an empty configuration if there is none.
+func loadConfig() (*config, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			content, exists, err := readIfExists(path)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				cfg, err := parseConfig(content, path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load config %s: %s", path, err)
+				}
+				return cfg, nil
+			}
+		}
+		if filepath.Dir(dir) == dir {
+			return &config{}, nil
+		}
+	}
+}
+
+func parseConfig(content []byte, path string) (*config, error) {
+	cfg := &config{dir: filepath.Dir(path)}
+	if filepath.Ext(path) == ".toml" {
+		md, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return nil, err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown key %q", undecoded[0].String())
+		}
+	} else if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Source != "" {
+		if _, err := language.Parse(cfg.Source); err != nil {
+			return nil, fmt.Errorf("invalid source language %q: %s", cfg.Source, err)
+		}
+	}
+	for _, lang := range cfg.Languages {
+		if _, err := language.Parse(lang); err != nil {
+			return nil, fmt.Errorf("invalid language %q: %s", lang, err)
+		}
+	}
+	return cfg, nil
+}
+
+// path returns the path p of the configuration relative to the working directory.
+func (cfg *config) path(p string) string {
+	if cfg.dir == "" || filepath.IsAbs(p) {
+		return p
+	}
+	p = filepath.Join(cfg.dir, p)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, p); err == nil {
+			return rel
+		}
+	}
+	return p
+}
+
+// source returns the source language of the configuration, or def if there is none.
+func (cfg *config) source(def language.Tag) languageTag {
+	if tag, err := language.Parse(cfg.Source); err == nil && cfg.Source != "" {
+		return languageTag(tag)
+	}
+	return languageTag(def)
+}
+
+// out returns the output directory of the configuration, or def if there is none.
+func (cfg *config) out(def string) string {
+	if cfg.Out == "" {
+		return def
+	}
+	return cfg.path(cfg.Out)
+}
+
+// file returns the path of the file p of the configuration, or def if p is empty.
+func (cfg *config) file(p, def string) string {
+	if p == "" {
+		return def
+	}
+	return cfg.path(p)
+}
+
+// format returns the output format of the configuration, or def if there is none.
+func (cfg *config) format(def string) string {
+	if cfg.Format == "" {
+		return def
+	}
+	return cfg.Format
+}
+
+// separator returns the separator of nested message ids of the configuration, or def if there is none.
+func (cfg *config) separator(def string) string {
+	if cfg.Separator == "" {
+		return def
+	}
+	return cfg.Separator
+}
+
+// paths returns the Go source paths of the configuration, or def if there are none.
+func (cfg *config) paths(def []string) []string {
+	if len(cfg.Paths) == 0 {
+		return def
+	}
+	paths := make([]string, len(cfg.Paths))
+	for i, p := range cfg.Paths {
+		paths[i] = cfg.path(p)
+	}
+	return paths
+}
+
+// messageFiles returns the message files matching the Messages patterns of the configuration.
+func (cfg *config) messageFiles() ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+	for _, pattern := range cfg.Messages {
+		matches, err := filepath.Glob(cfg.path(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid messages pattern %q: %s", pattern, err)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			if !seen[match] && !cfg.ignored(match) {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// ignored tells whether path, relative to the working directory, matches an Ignore pattern.
+func (cfg *config) ignored(path string) bool {
+	if len(cfg.Ignore) == 0 {
+		return false
+	}
+	rel := path
+	if abs, err := filepath.Abs(path); err == nil && cfg.dir != "" {
+		if r, err := filepath.Rel(cfg.dir, abs); err == nil {
+			rel = r
+		}
+	}
+	for _, pattern := range cfg.Ignore {
+		pattern = filepath.FromSlash(strings.TrimSuffix(pattern, "/"))
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// languages returns the target languages of the configuration.
+func (cfg *config) languages() []language.Tag {
+	tags := make([]language.Tag, 0, len(cfg.Languages))
+	for _, lang := range cfg.Languages {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// checkTemplates returns the problems of the templates of messageTemplates that do not parse,
+// with funcs being the names of the custom template functions.
+// Unknown functions are only reported if funcs is configured, since projects
+// without template_funcs may still pass custom functions to LocalizeConfig.Funcs.
+// The problems are warnings: the templates may still render with the funcs of the program.
+func checkTemplates(messageTemplates map[string]*i18n.MessageTemplate, funcs []string) []error {
+	funcMap := make(gotemplate.FuncMap, len(funcs))
+	for _, name := range funcs {
+		funcMap[name] = func(...interface{}) interface{} { return nil }
+	}
+	var problems []error
+	for _, id := range sortedIDs(messageTemplates) {
+		mt := messageTemplates[id]
+		for _, form := range pluralForms {
+			t := mt.PluralTemplates[form]
+			if t == nil {
+				continue
+			}
+			if _, err := gotemplate.New(id).Delims(t.LeftDelim, t.RightDelim).Funcs(funcMap).Parse(t.Src); err != nil {
+				if strings.Contains(err.Error(), "not defined") {
+					if funcs == nil {
+						continue
+					}
+					problems = append(problems, fmt.Errorf("invalid %s template of message %q: %s, custom template functions must be listed in template_funcs of %s", form, id, err, configFileNames[0]))
+					continue
+				}
+				problems = append(problems, fmt.Errorf("invalid %s template of message %q: %s", form, id, err))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// edit is a line of an edit script: kept (' '), deleted ('-') or inserted ('+').
+type edit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns the unified diff of the files from and to with the given labels,
+// or "" if they are equal.
+func unifiedDiff(fromLabel, toLabel string, from, to []byte) string {
+	if string(from) == string(to) {
+		return ""
+	}
+	edits := diffLines(splitLines(string(from)), splitLines(string(to)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromLabel, toLabel)
+	for start := 0; start < len(edits); {
+		// Find the next change and extend the hunk while changes are close enough.
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		last := first
+		for i := first; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				if i-last > 2*diffContext {
+					break
+				}
+				last = i
+			}
+		}
+		begin, end := max(start, first-diffContext), min(len(edits), last+1+diffContext)
+
+		// Line numbers of the hunk in both files.
+		fromLine, toLine := 1, 1
+		for _, e := range edits[:begin] {
+			if e.op != '+' {
+				fromLine++
+			}
+			if e.op != '-' {
+				toLine++
+			}
+		}
+		fromCount, toCount := 0, 0
+		for _, e := range edits[begin:end] {
+			if e.op != '+' {
+				fromCount++
+			}
+			if e.op != '-' {
+				toCount++
+			}
+		}
+		if fromCount == 0 {
+			fromLine--
+		}
+		if toCount == 0 {
+			toLine--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+		for _, e := range edits[begin:end] {
+			b.WriteByte(e.op)
+			if strings.HasSuffix(e.line, "\n") {
+				b.WriteString(e.line)
+			} else {
+				b.WriteString(e.line + "\n\\ No newline at end of file\n")
+			}
+		}
+		start = end
+	}
+	return b.String()
+}
+
+// splitLines splits s after each newline.
+func splitLines(s string) []string {
+	var lines []string
+	for s != "" {
+		i := strings.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+		lines, s = append(lines, s[:i]), s[i:]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script from a to b using the algorithm of Myers.
+func diffLines(a, b []string) []edit {
+	// Common prefixes and suffixes are kept as they are.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	var edits []edit
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{' ', line})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{' ', line})
+	}
+	return edits
+}
+
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		edits := make([]edit, 0, n+m)
+		for _, line := range a {
+			edits = append(edits, edit{'-', line})
+		}
+		for _, line := range b {
+			edits = append(edits, edit{'+', line})
+		}
+		return edits
+	}
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	// trace holds the furthest reaching x of the diagonals -d..d before each step d.
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, d)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack follows the furthest reaching paths of trace back from the end of a and b.
+func backtrack(a, b []string, trace [][]int, d int) []edit {
+	var edits []edit
+	x, y := len(a), len(b)
+	for ; d >= 0; d-- {
+		v := func(k int) int {
+			return trace[d][k+d]
+		}
+		k := x - y
+		var prevK int
+		if k == -d || k != d && v(k-1) < v(k+1) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = v(prevK)
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, edit{' ', a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{'+', b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{'-', a[x]})
+		}
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
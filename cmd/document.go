@@ -0,0 +1,663 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// orderedMap is a map whose entries are written to message files in order.
+type orderedMap []*orderedEntry
+
+type orderedEntry struct {
+	key string
+	// value is a string, a bool, an int, a []string, an orderedMap or a []orderedMap.
+	value interface{}
+	// comments are the comment lines written above the entry, including the comment markers.
+	comments []string
+}
+
+// fieldOrder is the order in which the fields of a message are written.
+var fieldOrder = []string{"id", "context", "description", "reference", "maxlength", "tags", "donottranslate", "placeholders", "hash", "status", "fuzzy", "machine_translated", "leftdelim", "rightdelim", "translation", "zero", "one", "two", "few", "many", "other", "source"}
+
+func fieldRank(key string) int {
+	for i, field := range fieldOrder {
+		if strings.ToLower(key) == field {
+			return i
+		}
+	}
+	return len(fieldOrder)
+}
+
+// keyLess sorts the fields of messages in fieldOrder and all other keys alphabetically.
+func keyLess(a, b string, isMessage bool) bool {
+	if isMessage {
+		if ra, rb := fieldRank(a), fieldRank(b); ra != rb {
+			return ra < rb
+		}
+	}
+	return a < b
+}
+
+// orderValue returns v, a value built by marshalValue, nestValue or v1Value, with ordered maps.
+// Keys are sorted, except that keys of the existing file described by style
+// keep their order and comments, so that rewriting a file only changes the lines of changed messages.
+func orderValue(v interface{}, style *fileStyle, path []string, isMessage bool) interface{} {
+	switch v := v.(type) {
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = value
+		}
+		return orderMap(m, style, path, true)
+	case messageValue:
+		return orderMap(v, style, path, true)
+	case map[string]interface{}:
+		return orderMap(v, style, path, isMessage)
+	case []interface{}:
+		// The messages of the v1 array layout are already sorted by id.
+		items := make([]orderedMap, 0, len(v))
+		for _, item := range v {
+			if m, ok := orderValue(item, nil, nil, true).(orderedMap); ok {
+				items = append(items, m)
+			}
+		}
+		return items
+	}
+	return v
+}
+
+func orderMap(m map[string]interface{}, style *fileStyle, path []string, isMessage bool) orderedMap {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	less := func(a, b string) bool {
+		return keyLess(a, b, isMessage)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	keys = style.order(path, keys, less)
+
+	om := make(orderedMap, 0, len(keys))
+	for _, key := range keys {
+		p := append(path[:len(path):len(path)], key)
+		om = append(om, &orderedEntry{
+			key:      key,
+			value:    orderValue(m[key], style, p, false),
+			comments: style.commentsOf(p),
+		})
+	}
+	return om
+}
+
+// fileStyle is the key order and the comments of an existing message file.
+type fileStyle struct {
+	// header is the comment at the top of the file that doesn't belong to any key.
+	header []string
+	// positions of the key paths in the order they appear in the file.
+	positions map[string]int
+	// comments above the key paths.
+	comments map[string][]string
+}
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// add records the key path found at pos with the comments above it.
+func (s *fileStyle) add(path []string, pos int, comments []string) {
+	for i := range path {
+		if _, ok := s.positions[pathKey(path[:i+1])]; !ok {
+			s.positions[pathKey(path[:i+1])] = pos
+		}
+	}
+	if len(comments) > 0 {
+		s.comments[pathKey(path)] = comments
+	}
+}
+
+// order returns the sorted keys of the map at path in the order of the existing file.
+// Keys that are not in the file are inserted before the first key they sort before.
+func (s *fileStyle) order(path []string, sorted []string, less func(a, b string) bool) []string {
+	if s == nil {
+		return sorted
+	}
+	var keys, added []string
+	for _, key := range sorted {
+		if _, ok := s.positions[pathKey(append(path[:len(path):len(path)], key))]; ok {
+			keys = append(keys, key)
+		} else {
+			added = append(added, key)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return s.positions[pathKey(append(path[:len(path):len(path)], keys[i]))] <
+			s.positions[pathKey(append(path[:len(path):len(path)], keys[j]))]
+	})
+	for _, key := range added {
+		i := 0
+		for i < len(keys) && !less(key, keys[i]) {
+			i++
+		}
+		keys = append(keys[:i], append([]string{key}, keys[i:]...)...)
+	}
+	return keys
+}
+
+func (s *fileStyle) commentsOf(path []string) []string {
+	if s == nil {
+		return nil
+	}
+	return s.comments[pathKey(path)]
+}
+
+// scanStyle returns the style of the existing message file content in format,
+// or nil if there is no such file.
+func scanStyle(content []byte, format string) *fileStyle {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil
+	}
+	s := &fileStyle{positions: map[string]int{}, comments: map[string][]string{}}
+	switch format {
+	case "json":
+		s.scanJSON(content)
+	case "toml":
+		s.scanTOML(content)
+	case "yaml":
+		s.scanYAML(content)
+	default:
+		return nil
+	}
+	return s
+}
+
+// scanJSON records the key order of content. JSON has no comments.
+func (s *fileStyle) scanJSON(content []byte) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	var walk func(path []string, record bool) error
+	walk = func(path []string, record bool) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := tok.(string)
+				p := append(path[:len(path):len(path)], key)
+				if record {
+					s.add(p, len(s.positions), nil)
+				}
+				if err := walk(p, record); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			// Arrays are lists of strings or the v1 layout, which is ordered by message id.
+			for dec.More() {
+				if err := walk(nil, false); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	// A file that fails to parse just keeps the order read so far.
+	_ = walk(nil, true)
+}
+
+// scanTOML records the keys and comments of content line by line.
+func (s *fileStyle) scanTOML(content []byte) {
+	var table, comments []string
+	lines := strings.Split(string(content), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			if len(s.positions) == 0 && s.header == nil {
+				s.header, comments = comments, nil
+			}
+		case strings.HasPrefix(line, "#"):
+			comments = append(comments, line)
+		case strings.HasPrefix(line, "[["):
+			// Arrays of tables are no messages.
+			table, comments = nil, nil
+		case strings.HasPrefix(line, "["):
+			keys, rest, ok := parseTOMLKey(line[1:])
+			if !ok || !strings.HasPrefix(rest, "]") {
+				comments = nil
+				continue
+			}
+			table = keys
+			s.add(table, i, comments)
+			comments = nil
+		default:
+			keys, rest, ok := parseTOMLKey(line)
+			if !ok || !strings.HasPrefix(rest, "=") {
+				comments = nil
+				continue
+			}
+			s.add(append(table[:len(table):len(table)], keys...), i, comments)
+			comments = nil
+			// Skip the lines of multi-line strings.
+			value := strings.TrimSpace(rest[1:])
+			for _, delim := range []string{`"""`, `'''`} {
+				if strings.HasPrefix(value, delim) && !strings.Contains(value[len(delim):], delim) {
+					for i++; i < len(lines) && !strings.Contains(lines[i], delim); i++ {
+					}
+				}
+			}
+		}
+	}
+}
+
+// parseTOMLKey parses the dotted key at the start of s and returns its parts and the rest of s.
+func parseTOMLKey(s string) (keys []string, rest string, ok bool) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return nil, "", false
+		}
+		var key string
+		switch s[0] {
+		case '"':
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, "", false
+			}
+			var err error
+			if key, err = strconv.Unquote(s[:end+1]); err != nil {
+				return nil, "", false
+			}
+			s = s[end+1:]
+		case '\'':
+			end := strings.IndexByte(s[1:], '\'')
+			if end < 0 {
+				return nil, "", false
+			}
+			key, s = s[1:end+1], s[end+2:]
+		default:
+			n := 0
+			for n < len(s) && isBareKeyChar(s[n]) {
+				n++
+			}
+			if n == 0 {
+				return nil, "", false
+			}
+			key, s = s[:n], s[n:]
+		}
+		keys = append(keys, key)
+		s = strings.TrimLeft(s, " \t")
+		if !strings.HasPrefix(s, ".") {
+			return keys, s, true
+		}
+		s = s[1:]
+	}
+}
+
+// closingQuote returns the index of the quote that closes the double-quoted string at the start of s, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func isBareKeyChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+// scanYAML records the keys and comments of content line by line,
+// using the indentation of the keys to find their parents.
+func (s *fileStyle) scanYAML(content []byte) {
+	type level struct {
+		indent int
+		key    string
+	}
+	var stack []level
+	var comments []string
+	skipIndent := -1
+	lines := strings.Split(string(content), "\n")
+	for i := range lines {
+		text := strings.TrimRight(lines[i], " \t\r")
+		line := strings.TrimLeft(text, " ")
+		indent := len(text) - len(line)
+		if skipIndent >= 0 {
+			if line == "" || indent > skipIndent {
+				continue
+			}
+			skipIndent = -1
+		}
+		switch {
+		case line == "":
+			if len(s.positions) == 0 && s.header == nil {
+				s.header, comments = comments, nil
+			}
+		case strings.HasPrefix(line, "#"):
+			comments = append(comments, line)
+		case line == "---":
+		case line == "-" || strings.HasPrefix(line, "- "):
+			// Sequences only occur in the v1 layout, which is ordered by message id.
+			comments, skipIndent = nil, indent
+		default:
+			key, rest, ok := parseYAMLKey(line)
+			if !ok {
+				comments = nil
+				continue
+			}
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			path := make([]string, 0, len(stack)+1)
+			for _, l := range stack {
+				path = append(path, l.key)
+			}
+			path = append(path, key)
+			s.add(path, i, comments)
+			comments = nil
+			stack = append(stack, level{indent: indent, key: key})
+			if value := strings.TrimSpace(rest); value != "" && !strings.HasPrefix(value, "#") {
+				// Skip the following lines of block, folded and multi-line scalars.
+				skipIndent = indent
+			}
+		}
+	}
+}
+
+// parseYAMLKey parses the key of a "key: value" line and returns the key and the value.
+func parseYAMLKey(line string) (key, value string, ok bool) {
+	var rest string
+	switch line[0] {
+	case '"':
+		end := closingQuote(line)
+		if end < 0 {
+			return "", "", false
+		}
+		var err error
+		if key, err = strconv.Unquote(line[:end+1]); err != nil {
+			return "", "", false
+		}
+		rest = line[end+1:]
+	case '\'':
+		end := 1
+		for ; end < len(line); end++ {
+			if line[end] == '\'' {
+				if end+1 < len(line) && line[end+1] == '\'' {
+					end++
+					continue
+				}
+				break
+			}
+		}
+		if end >= len(line) {
+			return "", "", false
+		}
+		key, rest = strings.Replace(line[1:end], "''", "'", -1), line[end+1:]
+	default:
+		end := strings.Index(line, ": ")
+		if end < 0 {
+			if !strings.HasSuffix(line, ":") {
+				return "", "", false
+			}
+			end = len(line) - 1
+		}
+		key, rest = strings.TrimSpace(line[:end]), line[end:]
+	}
+	rest = strings.TrimLeft(rest, " ")
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", false
+	}
+	return key, rest[1:], true
+}
+
+// writeJSON writes v like a json.Encoder with an indent of two spaces.
+func writeJSON(b *bytes.Buffer, v interface{}, indent string) {
+	switch v := v.(type) {
+	case string:
+		b.WriteString(jsonString(v))
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case []string:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, item := range v {
+			b.WriteString(indent + "  " + jsonString(item))
+			if i < len(v)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(indent + "]")
+	case orderedMap:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for i, e := range v {
+			b.WriteString(indent + "  " + jsonString(e.key) + ": ")
+			writeJSON(b, e.value, indent+"  ")
+			if i < len(v)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(indent + "}")
+	case []orderedMap:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, item := range v {
+			b.WriteString(indent + "  ")
+			writeJSON(b, item, indent+"  ")
+			if i < len(v)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(indent + "]")
+	}
+}
+
+// jsonString returns s as a JSON string without escaping HTML.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// writeTOML writes the table m at path.
+// Comments of tables without a header of their own are written above the first header of their subtables.
+func writeTOML(b *bytes.Buffer, m orderedMap, path []string, comments []string) {
+	// Plain values must come before the subtables of a table.
+	for _, e := range m {
+		switch value := e.value.(type) {
+		case string:
+			writeComments(b, e.comments, "")
+			b.WriteString(tomlKey(e.key) + " = " + tomlString(value) + "\n")
+		case bool:
+			writeComments(b, e.comments, "")
+			b.WriteString(tomlKey(e.key) + " = " + strconv.FormatBool(value) + "\n")
+		case int:
+			writeComments(b, e.comments, "")
+			b.WriteString(tomlKey(e.key) + " = " + strconv.Itoa(value) + "\n")
+		case []string:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = tomlString(item)
+			}
+			writeComments(b, e.comments, "")
+			b.WriteString(tomlKey(e.key) + " = [" + strings.Join(items, ", ") + "]\n")
+		}
+	}
+	for _, e := range m {
+		table, ok := e.value.(orderedMap)
+		if !ok {
+			continue
+		}
+		p := append(path[:len(path):len(path)], e.key)
+		pending := append(comments[:len(comments):len(comments)], e.comments...)
+		comments = nil
+		if hasTOMLValues(table) {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			writeComments(b, pending, "")
+			keys := make([]string, len(p))
+			for i, key := range p {
+				keys[i] = tomlKey(key)
+			}
+			b.WriteString("[" + strings.Join(keys, ".") + "]\n")
+			pending = nil
+		}
+		writeTOML(b, table, p, pending)
+	}
+}
+
+// hasTOMLValues tells whether table needs a header of its own.
+func hasTOMLValues(table orderedMap) bool {
+	if len(table) == 0 {
+		return true
+	}
+	for _, e := range table {
+		if _, ok := e.value.(orderedMap); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func tomlKey(key string) string {
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return tomlString(key)
+		}
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+// tomlString returns s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// writeYAML writes m as a block mapping with an indent of two spaces.
+func writeYAML(b *bytes.Buffer, m orderedMap, indent string) {
+	for _, e := range m {
+		writeComments(b, e.comments, indent)
+		b.WriteString(indent + yamlScalar(e.key) + ":")
+		switch value := e.value.(type) {
+		case string:
+			b.WriteString(" " + yamlScalar(value) + "\n")
+		case bool:
+			b.WriteString(" " + strconv.FormatBool(value) + "\n")
+		case int:
+			b.WriteString(" " + strconv.Itoa(value) + "\n")
+		case []string:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = jsonString(item)
+			}
+			b.WriteString(" [" + strings.Join(items, ", ") + "]\n")
+		case orderedMap:
+			if len(value) == 0 {
+				b.WriteString(" {}\n")
+				continue
+			}
+			b.WriteByte('\n')
+			writeYAML(b, value, indent+"  ")
+		}
+	}
+}
+
+// writeYAMLSequence writes the messages of the v1 array layout.
+func writeYAMLSequence(b *bytes.Buffer, items []orderedMap) {
+	for _, item := range items {
+		var buf bytes.Buffer
+		writeYAML(&buf, item, "  ")
+		b.WriteString("- ")
+		b.Write(bytes.TrimPrefix(buf.Bytes(), []byte("  ")))
+	}
+}
+
+// yamlScalar returns s as a YAML scalar on a single line.
+// Strings that yaml would write as a block or fold into several lines are double-quoted instead.
+func yamlScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if scalar := strings.TrimSuffix(string(out), "\n"); err == nil && !strings.Contains(scalar, "\n") {
+		return scalar
+	}
+	return jsonString(s)
+}
+
+func writeComments(b *bytes.Buffer, comments []string, indent string) {
+	for _, c := range comments {
+		b.WriteString(indent + c + "\n")
+	}
+}
+
+// writeHeader writes the header comment of the existing file followed by an empty line.
+func writeHeader(b *bytes.Buffer, style *fileStyle) {
+	if style == nil || len(style.header) == 0 {
+		return
+	}
+	writeComments(b, style.header, "")
+	b.WriteByte('\n')
+}
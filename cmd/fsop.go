@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fileSystemOp is the set of files a command writes and deletes.
+type fileSystemOp struct {
+	writeFiles  map[string][]byte
+	deleteFiles []string
+}
+
+// errOutOfDate is returned in check mode if running the command would change files.
+var errOutOfDate = errors.New("message files are out of date")
+
+// run applies op to the file system.
+// With dryRun, it prints the changes op would make as a unified diff instead,
+// and with check, it fails with errOutOfDate if op would change any file.
+func (op *fileSystemOp) run(dryRun, check bool) error {
+	if !dryRun && !check {
+		return op.apply()
+	}
+	changes, err := op.changes()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		for _, c := range changes {
+			fmt.Print(c.diff)
+		}
+	}
+	if check && len(changes) > 0 {
+		paths := make([]string, len(changes))
+		for i, c := range changes {
+			paths[i] = c.path
+		}
+		return fmt.Errorf("%w: %s", errOutOfDate, strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+// apply writes and deletes the files of op.
+func (op *fileSystemOp) apply() error {
+	for _, path := range op.sortedWrites() {
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, op.writeFiles[path], 0666); err != nil {
+			return err
+		}
+	}
+	for _, path := range op.deleteFiles {
+		// Files to delete aren't guaranteed to exist.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileChange is the change of a single file.
+type fileChange struct {
+	path string
+	diff string
+}
+
+// changes returns the changes op would make to the files on disk, ordered by path.
+func (op *fileSystemOp) changes() ([]*fileChange, error) {
+	var changes []*fileChange
+	for _, path := range op.sortedWrites() {
+		current, exists, err := readIfExists(path)
+		if err != nil {
+			return nil, err
+		}
+		content := op.writeFiles[path]
+		if exists && bytes.Equal(current, content) {
+			continue
+		}
+		from := path
+		if !exists {
+			from = os.DevNull
+		}
+		changes = append(changes, &fileChange{path: path, diff: unifiedDiff(from, path, current, content)})
+	}
+	deletes := append([]string(nil), op.deleteFiles...)
+	sort.Strings(deletes)
+	for _, path := range deletes {
+		current, exists, err := readIfExists(path)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		diff := unifiedDiff(path, os.DevNull, current, nil)
+		if diff == "" {
+			// An empty file is deleted.
+			diff = fmt.Sprintf("--- %s\n+++ %s\n", path, os.DevNull)
+		}
+		changes = append(changes, &fileChange{path: path, diff: diff})
+	}
+	return changes, nil
+}
+
+func (op *fileSystemOp) sortedWrites() []string {
+	paths := make([]string, 0, len(op.writeFiles))
+	for path := range op.writeFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// readIfExists returns the content of the file at path and whether it exists.
+func readIfExists(path string) ([]byte, bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return content, err == nil, err
+}
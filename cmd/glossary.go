@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v2"
+)
+
+// glossary holds terms of the source language that must or must not be translated in specific ways.
+type glossary struct {
+	Terms []*glossaryTerm `toml:"terms" yaml:"terms" json:"terms"`
+}
+
+// glossaryTerm is a term of the source language and the ways to translate it by language.
+type glossaryTerm struct {
+	Source string `toml:"source" yaml:"source" json:"source"`
+
+	// Translations are the translations the term must be translated as.
+	Translations map[string]string `toml:"translations" yaml:"translations" json:"translations"`
+
+	// Forbidden are the translations the term must not be translated as.
+	Forbidden map[string][]string `toml:"forbidden" yaml:"forbidden" json:"forbidden"`
+
+	pattern *regexp.Regexp
+}
+
+// glossaryViolation is a translation that does not follow a glossary term.
+type glossaryViolation struct {
+	langTag language.Tag
+	id      string
+	form    string
+	term    string
+	// required is the translation the term must be translated as, or "" if forbidden is used.
+	required  string
+	forbidden string
+}
+
+func (v *glossaryViolation) String() string {
+	if v.required != "" {
+		return fmt.Sprintf("warning: %s translation of %q (%s) should translate %q as %q", v.langTag, v.id, v.form, v.term, v.required)
+	}
+	return fmt.Sprintf("warning: %s translation of %q (%s) must not translate %q as %q", v.langTag, v.id, v.form, v.term, v.forbidden)
+}
+
+// parseGlossary parses the glossary at path in toml, yaml or json format.
+func parseGlossary(content []byte, path string) (*glossary, error) {
+	g := &glossary{}
+	var err error
+	switch filepath.Ext(path) {
+	case ".toml":
+		var md toml.MetaData
+		if md, err = toml.Decode(string(content), g); err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown key %q", md.Undecoded()[0].String())
+		}
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(content, g)
+	case ".json":
+		err = json.Unmarshal(content, g)
+	default:
+		return nil, fmt.Errorf("unsupported glossary format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range g.Terms {
+		if term.Source == "" {
+			return nil, fmt.Errorf("glossary term without source")
+		}
+		langs := make([]string, 0, len(term.Translations)+len(term.Forbidden))
+		for lang := range term.Translations {
+			langs = append(langs, lang)
+		}
+		for lang := range term.Forbidden {
+			langs = append(langs, lang)
+		}
+		for _, lang := range langs {
+			if _, err := language.Parse(lang); err != nil {
+				return nil, fmt.Errorf("invalid language %q of term %q: %s", lang, term.Source, err)
+			}
+		}
+		term.pattern = termPattern(term.Source)
+	}
+	return g, nil
+}
+
+// termPattern matches the term case insensitively as a whole word.
+func termPattern(term string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(term)
+	if regexp.MustCompile(`^\w`).MatchString(term) {
+		pattern = `\b` + pattern
+	}
+	if regexp.MustCompile(`\w$`).MatchString(term) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + pattern)
+}
+
+// check returns the violations of the glossary by the translations to langTag of the source messages in messageTemplates.
+// Untranslated plural forms are not checked.
+func (g *glossary) check(langTag language.Tag, sourceMessageTemplates, messageTemplates map[string]*i18n.MessageTemplate) []*glossaryViolation {
+	var violations []*glossaryViolation
+	for _, id := range sortedIDs(messageTemplates) {
+		src := sourceMessageTemplates[id]
+		if src == nil {
+			continue
+		}
+		for _, pluralForm := range pluralForms {
+			t := messageTemplates[id].PluralTemplates[pluralForm]
+			if t == nil || t.Src == "" || isSourceCopy(src, t) {
+				continue
+			}
+			source := placeholder(src, pluralForm).Src
+			for _, term := range g.Terms {
+				if !term.pattern.MatchString(source) {
+					continue
+				}
+				if required, ok := term.translation(langTag); ok && !strings.Contains(strings.ToLower(t.Src), strings.ToLower(required)) {
+					violations = append(violations, &glossaryViolation{langTag: langTag, id: id, form: string(pluralForm), term: term.Source, required: required})
+				}
+				for _, forbidden := range term.forbidden(langTag) {
+					if strings.Contains(strings.ToLower(t.Src), strings.ToLower(forbidden)) {
+						violations = append(violations, &glossaryViolation{langTag: langTag, id: id, form: string(pluralForm), term: term.Source, forbidden: forbidden})
+					}
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// translation returns the translation the term must be translated as to langTag.
+func (term *glossaryTerm) translation(langTag language.Tag) (string, bool) {
+	for lang, translation := range term.Translations {
+		if tag, err := language.Parse(lang); err == nil && tag == langTag {
+			return translation, true
+		}
+	}
+	return "", false
+}
+
+// forbidden returns the translations the term must not be translated as to langTag.
+func (term *glossaryTerm) forbidden(langTag language.Tag) []string {
+	var forbidden []string
+	for lang, translations := range term.Forbidden {
+		if tag, err := language.Parse(lang); err == nil && tag == langTag {
+			forbidden = append(forbidden, translations...)
+		}
+	}
+	sort.Strings(forbidden)
+	return forbidden
+}
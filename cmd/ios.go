@@ -0,0 +1,445 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/internal/plural"
+)
+
+// unmarshalStrings is an i18n.UnmarshalFunc for iOS Localizable.strings files.
+//
+// Every "key" = "value"; pair becomes a message with an "other" form,
+// a comment right before a pair becomes the description of its message
+// and metadata comments like /* hash: sha256-... */ its metadata.
+// Placeholders like %@ and %1$@ become the fields named by the /* args: Name */ comment of the pair,
+// or {{.Arg1}} if it has none.
+func unmarshalStrings(data []byte, v interface{}) error {
+	raw, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("unsupported value %T", v)
+	}
+	s := &stringsScanner{src: string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))}
+	messages := map[string]interface{}{}
+	for {
+		comment, key, err := s.token()
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			break
+		}
+		metadata, names := s.metadata, s.names
+		s.metadata, s.names = nil, nil
+		if _, eq, err := s.token(); err != nil || eq != "=" {
+			return s.errorf("expected = after %q", key)
+		}
+		_, value, err := s.token()
+		if err != nil || value == "" {
+			return s.errorf("expected value for %q", key)
+		}
+		if _, semi, err := s.token(); err != nil || semi != ";" {
+			return s.errorf("expected ; after value of %q", key)
+		}
+		m := map[string]interface{}{
+			"id":    unquoteStrings(key),
+			"other": fromPrintf(unquoteStrings(value), names),
+		}
+		if comment != "" {
+			m["description"] = comment
+		}
+		for k, v := range metadata {
+			m[k] = v
+		}
+		messages[m["id"].(string)] = m
+	}
+	*raw = messages
+	return nil
+}
+
+// stringsScanner splits a .strings file into quoted strings, words and the symbols = and ;.
+type stringsScanner struct {
+	src string
+	pos int
+	// metadata and names hold the metadata and args comments since the last pair, which are not returned as comments.
+	metadata map[string]interface{}
+	names    map[int]string
+}
+
+func (s *stringsScanner) errorf(format string, args ...interface{}) error {
+	line := strings.Count(s.src[:s.pos], "\n") + 1
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// token returns the next token and the last comment before it.
+// It returns an empty token at the end of the input.
+func (s *stringsScanner) token() (comment, tok string, err error) {
+	for s.pos < len(s.src) {
+		rest := s.src[s.pos:]
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			s.pos++
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest, "*/")
+			if end < 0 {
+				return "", "", s.errorf("unterminated comment")
+			}
+			s.comment(rest[2:end], &comment)
+			s.pos += end + 2
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			s.comment(rest[2:end], &comment)
+			s.pos += end
+		case rest[0] == '=' || rest[0] == ';':
+			s.pos++
+			return comment, rest[:1], nil
+		case rest[0] == '"':
+			for i := 1; i < len(rest); i++ {
+				switch rest[i] {
+				case '\\':
+					i++
+				case '"':
+					s.pos += i + 1
+					return comment, rest[:i+1], nil
+				}
+			}
+			return "", "", s.errorf("unterminated string")
+		default:
+			end := strings.IndexAny(rest, " \t\r\n=;")
+			if end < 0 {
+				end = len(rest)
+			}
+			s.pos += end
+			return comment, rest[:end], nil
+		}
+	}
+	return comment, "", nil
+}
+
+// comment records the text of a comment as metadata if it is a metadata comment, or else as the last comment.
+func (s *stringsScanner) comment(text string, comment *string) {
+	if s.metadata == nil {
+		s.metadata = map[string]interface{}{}
+	}
+	if names, ok := parseArgsComment(text); ok {
+		s.names = names
+	} else if !parseMetadataComment(text, s.metadata) {
+		*comment = strings.TrimSpace(text)
+	}
+}
+
+// unquoteStrings returns the value of a quoted string or word of a .strings file.
+func unquoteStrings(tok string) string {
+	if len(tok) < 2 || tok[0] != '"' {
+		return tok
+	}
+	s := tok[1 : len(tok)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'U', 'u':
+			if i+5 <= len(s) {
+				if u, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(u))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// marshalStrings returns the iOS Localizable.strings file for messageTemplates.
+// Plural messages are written with their "other" form,
+// their other forms belong into a .stringsdict file.
+func marshalStrings(messageTemplates map[string]*i18n.MessageTemplate) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, id := range sortedIDs(messageTemplates) {
+		mt := messageTemplates[id]
+		other := mt.PluralTemplates[plural.Other]
+		if other == nil {
+			continue
+		}
+		if mt.Desc != "" {
+			fmt.Fprintf(&buf, "/* %s */\n", strings.Replace(mt.Desc, "*/", "* /", -1))
+		}
+		args := printfArgs(mt)
+		comments := metadataComments(mt)
+		if comment := argsComment(args); comment != "" {
+			comments = append(comments, comment)
+		}
+		for _, comment := range comments {
+			fmt.Fprintf(&buf, "/* %s */\n", comment)
+		}
+		fmt.Fprintf(&buf, "%s = %s;\n\n", quoteStrings(id), quoteStrings(iosFormat(mt, other.Src, args, false)))
+	}
+	return buf.Bytes(), nil
+}
+
+// iosFormat converts the template src of mt to an iOS format string.
+// In a stringsdict variable the plural count is the value of the variable.
+// % is always escaped since the unmarshalers read every string as a format string.
+func iosFormat(mt *i18n.MessageTemplate, src string, args map[string]int, stringsdict bool) string {
+	var b strings.Builder
+	for _, seg := range splitFields(src, fieldActionRegexp(mt.LeftDelim, mt.RightDelim)) {
+		switch {
+		case seg.field == pluralCountField && stringsdict:
+			b.WriteString("%d")
+		case seg.field != "":
+			fmt.Fprintf(&b, "%%%d$%s", args[seg.field], printfVerb(seg.field, "@"))
+		default:
+			b.WriteString(strings.Replace(seg.text, "%", "%%", -1))
+		}
+	}
+	return b.String()
+}
+
+func quoteStrings(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// stringsdictVariable is the name of the variable that holds the plural count in written .stringsdict files.
+const stringsdictVariable = "count"
+
+// unmarshalStringsdict is an i18n.UnmarshalFunc for iOS .stringsdict plural dictionaries.
+//
+// Every entry becomes a message with the plural forms of its NSStringPluralRuleType variable,
+// and comments before an entry become its description or, like <!-- hash: sha256-... -->, its metadata.
+// Placeholders are named by the <!-- args: PluralCount, Name --> comment of the entry like in .strings files.
+// Text around the variable in NSStringLocalizedFormatKey is added to every form.
+// Entries with more than one variable are not supported.
+func unmarshalStringsdict(data []byte, v interface{}) error {
+	raw, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("unsupported value %T", v)
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return fmt.Errorf("missing <dict> in plist")
+		}
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "dict" {
+			break
+		}
+	}
+
+	messages := map[string]interface{}{}
+	comment, key := "", ""
+	metadata, names := map[string]interface{}{}, map[int]string(nil)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.Comment:
+			if n, ok := parseArgsComment(string(t)); ok {
+				names = n
+			} else if !parseMetadataComment(string(t), metadata) {
+				comment = strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			*raw = messages
+			return nil
+		case xml.StartElement:
+			if t.Name.Local == "key" {
+				if err := dec.DecodeElement(&key, &t); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := decodePlistValue(dec, t)
+			if err != nil {
+				return err
+			}
+			entry, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("expected a dictionary for %q", key)
+			}
+			m, err := stringsdictMessage(key, entry, names)
+			if err != nil {
+				return err
+			}
+			if comment != "" {
+				m["description"] = comment
+			}
+			for k, v := range metadata {
+				m[k] = v
+			}
+			comment, metadata, names = "", map[string]interface{}{}, nil
+			messages[key] = m
+		}
+	}
+}
+
+func stringsdictMessage(id string, entry map[string]interface{}, argNames map[int]string) (map[string]interface{}, error) {
+	format, _ := entry["NSStringLocalizedFormatKey"].(string)
+	start := strings.Index(format, "%#@")
+	if start < 0 {
+		return nil, fmt.Errorf("%q has no variable in NSStringLocalizedFormatKey", id)
+	}
+	end := strings.Index(format[start+3:], "@")
+	if end < 0 {
+		return nil, fmt.Errorf("%q has an invalid NSStringLocalizedFormatKey %q", id, format)
+	}
+	end += start + 3
+	prefix, suffix := format[:start], format[end+1:]
+	if strings.Contains(suffix, "%#@") {
+		return nil, fmt.Errorf("%q has more than one variable, which is not supported", id)
+	}
+	variable, ok := entry[format[start+3:end]].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%q has no variable %q", id, format[start+3:end])
+	}
+	names := map[int]string{1: pluralCountField}
+	for pos, name := range argNames {
+		names[pos] = name
+	}
+	m := map[string]interface{}{"id": id}
+	for _, form := range pluralForms {
+		if src, ok := variable[string(form)].(string); ok {
+			m[string(form)] = fromPrintf(prefix+src+suffix, names)
+		}
+	}
+	return m, nil
+}
+
+// decodePlistValue decodes the plist value that starts with start.
+// Dictionaries become maps, arrays slices and everything else strings.
+func decodePlistValue(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		m := map[string]interface{}{}
+		key := ""
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := tok.(type) {
+			case xml.EndElement:
+				return m, nil
+			case xml.StartElement:
+				if t.Name.Local == "key" {
+					if err := dec.DecodeElement(&key, &t); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				v, err := decodePlistValue(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				m[key] = v
+			}
+		}
+	case "array":
+		var a []interface{}
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := tok.(type) {
+			case xml.EndElement:
+				return a, nil
+			case xml.StartElement:
+				v, err := decodePlistValue(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, v)
+			}
+		}
+	case "true", "false":
+		return start.Name.Local, dec.Skip()
+	default:
+		var s string
+		err := dec.DecodeElement(&s, &start)
+		return s, err
+	}
+}
+
+// marshalStringsdict returns the iOS .stringsdict file for the plural messages of messageTemplates.
+func marshalStringsdict(messageTemplates map[string]*i18n.MessageTemplate) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	buf.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	for _, id := range sortedIDs(messageTemplates) {
+		mt := messageTemplates[id]
+		if len(mt.PluralTemplates) < 2 {
+			continue
+		}
+		args := printfArgs(mt)
+		if mt.Desc != "" {
+			fmt.Fprintf(&buf, "\t<!-- %s -->\n", strings.Replace(mt.Desc, "--", "- -", -1))
+		}
+		comments := metadataComments(mt)
+		if len(args) > 1 {
+			// The plural count is always the first argument, other fields need their names.
+			comments = append(comments, argsComment(args))
+		}
+		for _, comment := range comments {
+			fmt.Fprintf(&buf, "\t<!-- %s -->\n", comment)
+		}
+		fmt.Fprintf(&buf, "\t<key>%s</key>\n\t<dict>\n", xmlEscape(id))
+		fmt.Fprintf(&buf, "\t\t<key>NSStringLocalizedFormatKey</key>\n\t\t<string>%%#@%s@</string>\n", stringsdictVariable)
+		fmt.Fprintf(&buf, "\t\t<key>%s</key>\n\t\t<dict>\n", stringsdictVariable)
+		buf.WriteString("\t\t\t<key>NSStringFormatSpecTypeKey</key>\n\t\t\t<string>NSStringPluralRuleType</string>\n")
+		buf.WriteString("\t\t\t<key>NSStringFormatValueTypeKey</key>\n\t\t\t<string>d</string>\n")
+		for _, form := range pluralForms {
+			if t := mt.PluralTemplates[form]; t != nil {
+				fmt.Fprintf(&buf, "\t\t\t<key>%s</key>\n\t\t\t<string>%s</string>\n", form, xmlEscape(iosFormat(mt, t.Src, args, true)))
+			}
+		}
+		buf.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+	buf.WriteString("</dict>\n</plist>\n")
+	return buf.Bytes(), nil
+}
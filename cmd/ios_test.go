@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hollson/i18n"
+)
+
+func TestStringsRoundTrip(t *testing.T) {
+	var expected []*i18n.Message
+	for _, m := range roundTripMessages {
+		e := *m
+		if m.One != "" {
+			// Plural forms other than "other" belong into .stringsdict files.
+			e.One = ""
+		}
+		expected = append(expected, &e)
+	}
+	checkRoundTrip(t, roundTrip(t, "strings", marshalStrings, roundTripMessages), expected)
+}
+
+func TestStringsdictRoundTrip(t *testing.T) {
+	messages := []*i18n.Message{
+		{ID: "Cats", Desc: "Cats of a person", Hash: "sha256-abc", Fuzzy: true, One: "{{.Name}} has {{.PluralCount}} cat", Other: "{{.Name}} has {{.PluralCount}} cats"},
+		{ID: "Done", One: "{{.PluralCount}}% done & <ok>", Other: "{{.PluralCount}}%% done"},
+		{ID: "Items", Zero: "no items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"},
+	}
+	checkRoundTrip(t, roundTrip(t, "stringsdict", marshalStringsdict, messages), messages)
+}
@@ -14,6 +14,10 @@ Usage:
 Command:
     extract	从go源码提取「i18n.Message」,即预翻译的消息(不包含测试文件)
     merge	合并翻译文件
+    convert	转换消息文件格式(json,toml,yaml,xml,strings,stringsdict)
+    add-language	添加目标语言,创建按其复数规则展开的translate文件
+    translate	调用机器翻译服务预填translate文件
+    lint	检查翻译的最大长度和不可翻译的词语
 
 `)
 }
@@ -29,8 +33,11 @@ func main() {
 	flags := flag.NewFlagSet("i18n_cli", flag.ContinueOnError)
 
 	flags.Usage = usage
-	if err := flags.Parse(os.Args[1:]); err != nil {
-		fmt.Println(err)
+	if err := flags.Parse(os.Args[1:]); err == flag.ErrHelp {
+		os.Exit(0)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
 
 	if len(os.Args) == 1 {
@@ -40,17 +47,30 @@ func main() {
 	commands := []command{
 		&mergeCommand{},
 		&extractCommand{},
+		&convertCommand{},
+		&addLanguageCommand{},
+		&translateCommand{},
+		&lintCommand{},
+		&exportCommand{},
 	}
 	cmdName := flags.Arg(0)
 	for _, cmd := range commands {
 		if cmd.name() == cmdName {
 			if err := cmd.parse(flags.Args()[1:]); err != nil {
 				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
 			}
 			if err := cmd.execute(); err != nil {
 				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
 			}
+			return
 		}
 	}
+	if cmdName != "" {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", cmdName)
+		usage()
+		os.Exit(2)
+	}
 }
 
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/hollson/i18n"
@@ -14,56 +16,324 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func writeFile(outdir, label string, langTag language.Tag, format string, messageTemplates map[string]*i18n.MessageTemplate, sourceLanguage bool) (path string, content []byte, err error) {
-	v := marshalValue(messageTemplates, sourceLanguage)
-	content, err = marshal(v, format)
+// unmarshalFuncs are the UnmarshalFuncs of all the formats that i18n_cli reads.
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"json":        json.Unmarshal,
+	"toml":        toml.Unmarshal,
+	"yaml":        yaml.Unmarshal,
+	"xml":         unmarshalAndroid,
+	"strings":     unmarshalStrings,
+	"stringsdict": unmarshalStringsdict,
+}
+
+// writeFile returns the path and content of a message file.
+// If nestedSeparator is not empty, message ids are split by it into nested namespaces.
+// existing holds the current content of message files by path,
+// so that rewriting a file keeps its key order and comments.
+func writeFile(outdir, label string, langTag language.Tag, format string, messageTemplates map[string]*i18n.MessageTemplate, sourceLanguage bool, nestedSeparator string, existing map[string][]byte) (path string, content []byte, err error) {
+	path = messageFilePath(outdir, label, langTag, format)
+	switch format {
+	case "xml":
+		content, err = marshalAndroid(messageTemplates)
+	case "strings":
+		content, err = marshalStrings(messageTemplates)
+	case "stringsdict":
+		content, err = marshalStringsdict(messageTemplates)
+	default:
+		v := marshalValue(messageTemplates, sourceLanguage)
+		if nestedSeparator != "" {
+			v, err = nestValue(v, nestedSeparator)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to nest %s strings: %s", langTag, err)
+			}
+		}
+		content, err = marshal(v, format, existing[path])
+	}
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to marshal %s strings to %s: %s", langTag, format, err)
 	}
-	path = filepath.Join(outdir, fmt.Sprintf("%s.%s.%s", label, langTag, format))
 	return
 }
 
-func marshalValue(messageTemplates map[string]*i18n.MessageTemplate, sourceLanguage bool) interface{} {
+// messageFilePath returns the path of the message file with label for langTag in format.
+func messageFilePath(outdir, label string, langTag language.Tag, format string) string {
+	return filepath.Join(outdir, fmt.Sprintf("%s.%s.%s", label, langTag, format))
+}
+
+func marshalValue(messageTemplates map[string]*i18n.MessageTemplate, sourceLanguage bool) map[string]interface{} {
 	v := make(map[string]interface{}, len(messageTemplates))
 	for id, template := range messageTemplates {
-		if other := template.PluralTemplates[plural.Other]; sourceLanguage && len(template.PluralTemplates) == 1 &&
-			other != nil && template.Desc == "" && template.LeftDelim == "" && template.RightDelim == "" {
-			v[id] = other.Src
-		} else {
-			m := map[string]string{}
-			if template.Desc != "" {
-				m["description"] = template.Desc
+		v[id] = marshalMessage(template, sourceLanguage)
+	}
+	return v
+}
+
+// messageValue is the value of a message with fields other than strings.
+// It is a distinct type so that it is never mistaken for a namespace of nested messages.
+type messageValue map[string]interface{}
+
+// marshalMessage returns the value of a single message.
+// Messages of the source language are written without hash,
+// and as a plain string if they only have an "other" form.
+// Translations also record whether they are fuzzy or machine translated and the source they were translated from.
+func marshalMessage(template *i18n.MessageTemplate, sourceLanguage bool) interface{} {
+	if other := template.PluralTemplates[plural.Other]; sourceLanguage && len(template.PluralTemplates) == 1 &&
+		other != nil && template.Desc == "" && template.Context == "" && template.LeftDelim == "" && template.RightDelim == "" &&
+		template.Reference == "" && template.Status == "" && !hasListMetadata(template) {
+		return other.Src
+	}
+	m := map[string]string{}
+	if template.Desc != "" {
+		m["description"] = template.Desc
+	}
+	if template.Context != "" {
+		m["context"] = template.Context
+	}
+	if template.Reference != "" {
+		m["reference"] = template.Reference
+	}
+	if template.Status != "" {
+		m["status"] = template.Status
+	}
+	if template.LeftDelim != "" {
+		m["leftdelim"] = template.LeftDelim
+	}
+	if template.RightDelim != "" {
+		m["rightdelim"] = template.RightDelim
+	}
+	if !sourceLanguage {
+		m["hash"] = template.Hash
+	}
+	for pluralForm, template := range template.PluralTemplates {
+		m[string(pluralForm)] = template.Src
+	}
+	if !hasListMetadata(template) && (sourceLanguage || (!template.Fuzzy && !template.MachineTranslated && len(template.Source) == 0)) {
+		return m
+	}
+
+	v := make(messageValue, len(m)+7)
+	for key, value := range m {
+		v[key] = value
+	}
+	addListMetadata(v, template)
+	if sourceLanguage {
+		return v
+	}
+	if template.Fuzzy {
+		v["fuzzy"] = true
+	}
+	if template.MachineTranslated {
+		v["machine_translated"] = true
+	}
+	if len(template.Source) > 0 {
+		v["source"] = sourceValue(template.Source)
+	}
+	return v
+}
+
+// hasListMetadata tells whether template has metadata for translators that is not written as a string.
+func hasListMetadata(template *i18n.MessageTemplate) bool {
+	return template.MaxLength > 0 || len(template.Tags) > 0 || len(template.DoNotTranslate) > 0 || len(template.Placeholders) > 0
+}
+
+// addListMetadata adds the metadata of template that is not written as a string to v.
+func addListMetadata(v map[string]interface{}, template *i18n.MessageTemplate) {
+	if template.MaxLength > 0 {
+		v["maxlength"] = template.MaxLength
+	}
+	if len(template.Tags) > 0 {
+		v["tags"] = template.Tags
+	}
+	if len(template.DoNotTranslate) > 0 {
+		v["donottranslate"] = template.DoNotTranslate
+	}
+	if len(template.Placeholders) > 0 {
+		v["placeholders"] = template.Placeholders
+	}
+}
+
+// sourceValue returns the value of the source a translation was translated from,
+// a plain string if it only has an "other" form.
+func sourceValue(source map[string]string) interface{} {
+	if other, ok := source[string(plural.Other)]; ok && len(source) == 1 {
+		return other
+	}
+	return source
+}
+
+// reservedKeys are the keys that make a map a message instead of a namespace of nested messages.
+var reservedKeys = []string{"id", "description", "hash", "leftdelim", "rightdelim", "zero", "one", "two", "few", "many", "other"}
+
+// nestValue rebuilds the nested layout of messages from flat, a map of message ids to message values
+// whose ids contain the namespaces of the message separated by separator, e.g. "errors.db.timeout".
+// It fails if an id is both a message and the namespace of other messages.
+func nestValue(flat map[string]interface{}, separator string) (map[string]interface{}, error) {
+	ids := make([]string, 0, len(flat))
+	for id := range flat {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	nested := map[string]interface{}{}
+	for _, id := range ids {
+		if separator != defaultNestedSeparator && strings.Contains(id, defaultNestedSeparator) {
+			// Nested message files are read with the namespaces joined by ".", see splitNestedIDs.
+			return nil, fmt.Errorf("message id %q contains %q, which cannot be told apart from the separator %q when the nested file is read", id, defaultNestedSeparator, separator)
+		}
+		keys := strings.Split(id, separator)
+		m := nested
+		for i, key := range keys[:len(keys)-1] {
+			child, ok := m[key]
+			if !ok {
+				namespace := map[string]interface{}{}
+				m[key] = namespace
+				m = namespace
+				continue
 			}
-			if !sourceLanguage {
-				m["hash"] = template.Hash
+			namespace, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("message id %q is also the namespace of message id %q", strings.Join(keys[:i+1], separator), id)
 			}
+			m = namespace
+		}
+		key := keys[len(keys)-1]
+		if _, ok := m[key].(map[string]interface{}); ok {
+			return nil, fmt.Errorf("message id %q is also the namespace of other messages", id)
+		}
+		value := flat[id]
+		if src, ok := value.(string); ok && isReservedKey(key) {
+			// A plain string under a reserved key would turn its namespace into a message.
+			value = map[string]string{string(plural.Other): src}
+		}
+		m[key] = value
+	}
+	return nested, nil
+}
+
+// splitNestedIDs replaces the "." that joins the namespaces of the message ids of mf, read from content,
+// with separator if mf is a nested message file, so that ids written with a custom separator read back unchanged.
+// nestValue rejects ids that contain "." for a custom separator, so every "." in the ids is a separator.
+func splitNestedIDs(mf *i18n.MessageFile, content []byte, separator string) error {
+	if separator == "" || separator == defaultNestedSeparator || unmarshalFuncs[mf.Format] == nil {
+		return nil
+	}
+	var raw interface{}
+	if err := unmarshalFuncs[mf.Format](content, &raw); err != nil {
+		return err
+	}
+	if messageLayout(raw, mf.Messages) != nestedLayout {
+		return nil
+	}
+	for _, m := range mf.Messages {
+		m.ID = strings.ReplaceAll(m.ID, defaultNestedSeparator, separator)
+	}
+	return nil
+}
+
+func isReservedKey(key string) bool {
+	for _, k := range reservedKeys {
+		if strings.ToLower(key) == k {
+			return true
+		}
+	}
+	return false
+}
+
+// v1Value returns the messages of messageTemplates in the array layout of v1 message files.
+func v1Value(messageTemplates map[string]*i18n.MessageTemplate) []interface{} {
+	v := make([]interface{}, 0, len(messageTemplates))
+	for _, id := range sortedIDs(messageTemplates) {
+		template := messageTemplates[id]
+		m := map[string]interface{}{"id": id}
+		if template.Desc != "" {
+			m["description"] = template.Desc
+		}
+		if template.Context != "" {
+			m["context"] = template.Context
+		}
+		if template.Reference != "" {
+			m["reference"] = template.Reference
+		}
+		if template.Status != "" {
+			m["status"] = template.Status
+		}
+		addListMetadata(m, template)
+		if template.Hash != "" {
+			m["hash"] = template.Hash
+		}
+		if template.LeftDelim != "" {
+			m["leftdelim"] = template.LeftDelim
+		}
+		if template.RightDelim != "" {
+			m["rightdelim"] = template.RightDelim
+		}
+		if template.Fuzzy {
+			m["fuzzy"] = true
+		}
+		if template.MachineTranslated {
+			m["machine_translated"] = true
+		}
+		if len(template.Source) > 0 {
+			m["source"] = sourceValue(template.Source)
+		}
+		if other := template.PluralTemplates[plural.Other]; other != nil && len(template.PluralTemplates) == 1 {
+			m["translation"] = other.Src
+		} else {
+			translation := map[string]string{}
 			for pluralForm, template := range template.PluralTemplates {
-				m[string(pluralForm)] = template.Src
+				translation[string(pluralForm)] = template.Src
 			}
-			v[id] = m
+			m["translation"] = translation
 		}
+		v = append(v, m)
 	}
 	return v
 }
 
-func marshal(v interface{}, format string) ([]byte, error) {
-	switch format {
-	case "json":
-		var buf bytes.Buffer
-		enc := json.NewEncoder(&buf)
-		enc.SetEscapeHTML(false)
-		enc.SetIndent("", "  ")
-		err := enc.Encode(v)
-		return buf.Bytes(), err
-	case "toml":
-		var buf bytes.Buffer
-		enc := toml.NewEncoder(&buf)
-		enc.Indent = ""
-		err := enc.Encode(v)
-		return buf.Bytes(), err
-	case "yaml":
-		return yaml.Marshal(v)
-	}
-	return nil, fmt.Errorf("unsupported format: %s", format)
+// marshal returns the message file content of v in format.
+// Keys are written in sorted order, or in the order of previous,
+// the current content of the file, whose comments are kept.
+func marshal(v interface{}, format string, previous []byte) ([]byte, error) {
+	style := scanStyle(previous, format)
+	var buf bytes.Buffer
+	switch ordered := orderValue(v, style, nil, false).(type) {
+	case orderedMap:
+		switch format {
+		case "json":
+			writeJSON(&buf, ordered, "")
+			buf.WriteByte('\n')
+		case "toml":
+			if len(ordered) > 0 {
+				writeHeader(&buf, style)
+				writeTOML(&buf, ordered, nil, nil)
+			}
+		case "yaml":
+			if len(ordered) == 0 {
+				buf.WriteString("{}\n")
+				break
+			}
+			writeHeader(&buf, style)
+			writeYAML(&buf, ordered, "")
+		default:
+			return nil, fmt.Errorf("unsupported format: %s", format)
+		}
+	case []orderedMap:
+		switch format {
+		case "json":
+			writeJSON(&buf, ordered, "")
+			buf.WriteByte('\n')
+		case "yaml":
+			if len(ordered) == 0 {
+				buf.WriteString("[]\n")
+				break
+			}
+			writeHeader(&buf, style)
+			writeYAMLSequence(&buf, ordered)
+		default:
+			return nil, fmt.Errorf("unsupported format for the v1 layout: %s", format)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported value %T", v)
+	}
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+// goldenMessages covers the escaping of quotes, backslashes, control characters and unicode.
+var goldenMessages = []*i18n.Message{
+	{ID: "Quotes", Other: "It's \"quoted\" \\ here\nand\ttabbed"},
+	{ID: "Unicode", Other: "Привет, 世界 ✓"},
+	{ID: "Cats", Desc: "Cats: a \"list\"", One: "{{.PluralCount}} cat", Other: "{{.PluralCount}} cats"},
+	{ID: "yes", Other: "true"},
+}
+
+func goldenTemplates(messages []*i18n.Message) map[string]*i18n.MessageTemplate {
+	messageTemplates := map[string]*i18n.MessageTemplate{}
+	for _, m := range messages {
+		messageTemplates[m.ID] = i18n.NewMessageTemplate(m)
+	}
+	return messageTemplates
+}
+
+func TestWriteFileGolden(t *testing.T) {
+	tests := map[string]string{
+		"json": `{
+  "Cats": {
+    "description": "Cats: a \"list\"",
+    "one": "{{.PluralCount}} cat",
+    "other": "{{.PluralCount}} cats"
+  },
+  "Quotes": "It's \"quoted\" \\ here\nand\ttabbed",
+  "Unicode": "Привет, 世界 ✓",
+  "yes": "true"
+}
+`,
+		"toml": `Quotes = "It's \"quoted\" \\ here\nand\ttabbed"
+Unicode = "Привет, 世界 ✓"
+yes = "true"
+
+[Cats]
+description = "Cats: a \"list\""
+one = "{{.PluralCount}} cat"
+other = "{{.PluralCount}} cats"
+`,
+		"yaml": `Cats:
+  description: 'Cats: a "list"'
+  one: '{{.PluralCount}} cat'
+  other: '{{.PluralCount}} cats'
+Quotes: "It's \"quoted\" \\ here\nand\ttabbed"
+Unicode: Привет, 世界 ✓
+"yes": "true"
+`,
+	}
+	for format, expected := range tests {
+		_, content, err := writeFile("", "active", language.English, format, goldenTemplates(goldenMessages), true, "", nil)
+		if err != nil {
+			t.Errorf("%s: %s", format, err)
+			continue
+		}
+		if string(content) != expected {
+			t.Errorf("%s: wrote\n%s\nexpected\n%s", format, content, expected)
+		}
+	}
+}
+
+func TestWriteFileEscaping(t *testing.T) {
+	messages := append(goldenMessages,
+		&i18n.Message{ID: "Separators", Other: "line\u2028paragraph\u2029end"},
+		&i18n.Message{ID: "Control", Other: "bell\a, escape\x1b, carriage\r"},
+		&i18n.Message{ID: "Padded", Other: "  # not a comment: "},
+		&i18n.Message{ID: "Emoji", Other: "🐈 \U0001F408"},
+	)
+	for _, format := range []string{"json", "toml", "yaml"} {
+		for _, sourceLanguage := range []bool{true, false} {
+			path, content, err := writeFile("", "active", language.English, format, goldenTemplates(messages), sourceLanguage, "", nil)
+			if err != nil {
+				t.Errorf("%s: %s", format, err)
+				continue
+			}
+			mf, err := i18n.ParseMessageFileBytes(content, path, unmarshalFuncs)
+			if err != nil {
+				t.Errorf("%s: failed to read back\n%s\n%s", format, content, err)
+				continue
+			}
+			read := map[string]*i18n.Message{}
+			for _, m := range mf.Messages {
+				read[m.ID] = m
+			}
+			for _, m := range messages {
+				if r := read[m.ID]; r == nil || r.Other != m.Other || r.One != m.One || r.Desc != m.Desc {
+					t.Errorf("%s: message %s read back as %#v", format, m.ID, r)
+				}
+			}
+		}
+	}
+}
+
+func TestWriteFileRewrite(t *testing.T) {
+	messages := append(goldenMessages[:len(goldenMessages):len(goldenMessages)], &i18n.Message{ID: "Added", Other: "new"})
+	tests := []struct {
+		format   string
+		existing string
+		expected string
+	}{
+		{
+			format: "json",
+			existing: `{
+  "Unicode": "old",
+  "Cats": {
+    "other": "{{.PluralCount}} cats",
+    "one": "{{.PluralCount}} cat",
+    "description": "Cats"
+  },
+  "yes": "true",
+  "Quotes": "old"
+}
+`,
+			expected: `{
+  "Added": "new",
+  "Unicode": "Привет, 世界 ✓",
+  "Cats": {
+    "other": "{{.PluralCount}} cats",
+    "one": "{{.PluralCount}} cat",
+    "description": "Cats: a \"list\""
+  },
+  "yes": "true",
+  "Quotes": "It's \"quoted\" \\ here\nand\ttabbed"
+}
+`,
+		},
+		{
+			format: "toml",
+			existing: `# Messages of the example app.
+
+# Greeting in several scripts.
+Unicode = "old"
+yes = "true"
+Quotes = "old"
+
+# Cats of a person.
+[Cats]
+other = "{{.PluralCount}} cats"
+# The singular form.
+one = "{{.PluralCount}} cat"
+description = "Cats"
+`,
+			expected: `# Messages of the example app.
+
+Added = "new"
+# Greeting in several scripts.
+Unicode = "Привет, 世界 ✓"
+yes = "true"
+Quotes = "It's \"quoted\" \\ here\nand\ttabbed"
+
+# Cats of a person.
+[Cats]
+other = "{{.PluralCount}} cats"
+# The singular form.
+one = "{{.PluralCount}} cat"
+description = "Cats: a \"list\""
+`,
+		},
+		{
+			format: "yaml",
+			existing: `# Messages of the example app.
+
+# Greeting in several scripts.
+Unicode: old
+Cats:
+  # The plural form.
+  other: '{{.PluralCount}} cats'
+  one: '{{.PluralCount}} cat'
+  description: Cats
+"yes": "true"
+Quotes: old
+`,
+			expected: `# Messages of the example app.
+
+Added: new
+# Greeting in several scripts.
+Unicode: Привет, 世界 ✓
+Cats:
+  # The plural form.
+  other: '{{.PluralCount}} cats'
+  one: '{{.PluralCount}} cat'
+  description: 'Cats: a "list"'
+"yes": "true"
+Quotes: "It's \"quoted\" \\ here\nand\ttabbed"
+`,
+		},
+	}
+	for _, test := range tests {
+		path := messageFilePath("", "active", language.English, test.format)
+		existing := map[string][]byte{path: []byte(test.existing)}
+		_, content, err := writeFile("", "active", language.English, test.format, goldenTemplates(messages), true, "", existing)
+		if err != nil {
+			t.Errorf("%s: %s", test.format, err)
+			continue
+		}
+		if string(content) != test.expected {
+			t.Errorf("%s: rewrote\n%s\nexpected\n%s", test.format, content, test.expected)
+		}
+
+		// Writing the same messages again changes nothing.
+		existing[path] = content
+		_, again, err := writeFile("", "active", language.English, test.format, goldenTemplates(messages), true, "", existing)
+		if err != nil {
+			t.Errorf("%s: %s", test.format, err)
+			continue
+		}
+		if string(again) != string(content) {
+			t.Errorf("%s: second run wrote\n%s\nfirst run wrote\n%s", test.format, again, content)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/internal"
+	"github.com/hollson/i18n/internal/plural"
+	"golang.org/x/text/language"
+)
+
+// minSimilarity is the similarity of source texts a fuzzy match of the translation memory needs at least.
+const minSimilarity = 0.75
+
+// translationMemory holds the translations of source texts, by language and the hash of the source text.
+// merge fills it with the active translations and suggests them for untranslated messages with a similar source.
+type translationMemory map[string]map[string]*memoryEntry
+
+// memoryEntry is a source text and its translations by plural form.
+type memoryEntry struct {
+	Source       string            `json:"source"`
+	Translations map[string]string `json:"translations"`
+}
+
+// memorySuggestion is a translation suggested by the translation memory.
+type memorySuggestion struct {
+	source      string
+	translation string
+}
+
+// parseMemory returns the translation memory stored in content, or an empty one if content is empty.
+func parseMemory(content []byte) (translationMemory, error) {
+	tm := translationMemory{}
+	if len(content) == 0 {
+		return tm, nil
+	}
+	if err := json.Unmarshal(content, &tm); err != nil {
+		return nil, err
+	}
+	return tm, nil
+}
+
+// marshal returns the content of the file that stores tm.
+func (tm translationMemory) marshal() ([]byte, error) {
+	content, err := json.MarshalIndent(tm, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// textHash identifies a source text in the translation memory.
+func textHash(text string) string {
+	return fmt.Sprintf("sha256-%x", sha256.Sum256([]byte(text)))
+}
+
+// add records the translation of the plural form of the source text to langTag.
+func (tm translationMemory) add(langTag language.Tag, source string, pluralForm plural.Form, translation string) {
+	lang := langTag.String()
+	if tm[lang] == nil {
+		tm[lang] = make(map[string]*memoryEntry)
+	}
+	key := textHash(source)
+	entry := tm[lang][key]
+	if entry == nil {
+		entry = &memoryEntry{Source: source, Translations: make(map[string]string)}
+		tm[lang][key] = entry
+	}
+	entry.Translations[string(pluralForm)] = translation
+}
+
+// addTranslations records the translations of the source messages in messageTemplates.
+func (tm translationMemory) addTranslations(langTag language.Tag, sourceMessageTemplates, messageTemplates map[string]*i18n.MessageTemplate) {
+	for _, id := range sortedIDs(messageTemplates) {
+		src := sourceMessageTemplates[id]
+		if src == nil {
+			continue
+		}
+		for pluralForm, t := range messageTemplates[id].PluralTemplates {
+			if t.Src != "" && !isSourceCopy(src, t) {
+				tm.add(langTag, placeholder(src, pluralForm).Src, pluralForm, t.Src)
+			}
+		}
+	}
+}
+
+// suggest returns the translation of the plural form of the source text to langTag,
+// or else the translation of the most similar source text.
+func (tm translationMemory) suggest(langTag language.Tag, source string, pluralForm plural.Form) *memorySuggestion {
+	entries := tm[langTag.String()]
+	if entry := entries[textHash(source)]; entry != nil {
+		if translation, ok := entry.Translations[string(pluralForm)]; ok {
+			return &memorySuggestion{source: entry.Source, translation: translation}
+		}
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var best *memorySuggestion
+	bestSimilarity := minSimilarity
+	for _, key := range keys {
+		entry := entries[key]
+		translation, ok := entry.Translations[string(pluralForm)]
+		if !ok {
+			continue
+		}
+		if similarity := similarity(source, entry.Source); similarity >= bestSimilarity && (best == nil || similarity > bestSimilarity) {
+			best, bestSimilarity = &memorySuggestion{source: entry.Source, translation: translation}, similarity
+		}
+	}
+	return best
+}
+
+// suggestTranslations fills the untranslated plural forms of the translation dst of src
+// with the suggestions of the translation memory, and marks dst as fuzzy if there are any.
+// The source text of each suggestion is recorded so translators can compare it with the source.
+func (tm translationMemory) suggestTranslations(langTag language.Tag, src, dst *i18n.MessageTemplate) {
+	for pluralForm, t := range dst.PluralTemplates {
+		if !isSourceCopy(src, t) {
+			continue
+		}
+		suggestion := tm.suggest(langTag, t.Src, pluralForm)
+		if suggestion == nil {
+			continue
+		}
+		dst.PluralTemplates[pluralForm] = &internal.Template{Src: suggestion.translation, LeftDelim: t.LeftDelim, RightDelim: t.RightDelim}
+		if dst.Source == nil {
+			dst.Source = make(map[string]string)
+		}
+		dst.Source[string(pluralForm)] = suggestion.source
+		dst.Fuzzy = true
+	}
+}
+
+// sources returns the source texts that the plural forms of the stale translation dst to langTag
+// were translated from, as far as the translation memory recorded them.
+func (tm translationMemory) sources(langTag language.Tag, dst *i18n.MessageTemplate) map[string]string {
+	entries := tm[langTag.String()]
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sources map[string]string
+	for pluralForm, t := range dst.PluralTemplates {
+		for _, key := range keys {
+			if translation, ok := entries[key].Translations[string(pluralForm)]; ok && translation == t.Src {
+				if sources == nil {
+					sources = make(map[string]string)
+				}
+				sources[string(pluralForm)] = entries[key].Source
+				break
+			}
+		}
+	}
+	return sources
+}
+
+// similarity returns how similar the texts a and b are, from 0 for completely different to 1 for equal,
+// based on the edit distance of their characters.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	// Texts whose lengths differ too much cannot be similar enough.
+	if float64(longest-min(len(ra), len(rb))) > (1-minSimilarity)*float64(longest) {
+		return 0
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hollson/i18n"
+	"github.com/hollson/i18n/internal/plural"
+)
+
+// pluralForms is the order in which plural forms are written to files.
+var pluralForms = []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+
+// pluralCountField is the template field that holds the plural count.
+const pluralCountField = "PluralCount"
+
+// segment is either literal text or a reference to a template field like {{.Name}}.
+type segment struct {
+	text  string
+	field string
+}
+
+// fieldActionRegexp returns a regexp matching the template actions
+// that only print a field of the template data, e.g. {{.Name}}.
+func fieldActionRegexp(leftDelim, rightDelim string) *regexp.Regexp {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(leftDelim) + `-?\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*-?` + regexp.QuoteMeta(rightDelim))
+}
+
+// splitFields splits the template src into text and field segments.
+// Any other template action is kept as text.
+func splitFields(src string, re *regexp.Regexp) []segment {
+	var segments []segment
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(src, -1) {
+		if loc[0] > last {
+			segments = append(segments, segment{text: src[last:loc[0]]})
+		}
+		segments = append(segments, segment{field: src[loc[2]:loc[3]]})
+		last = loc[1]
+	}
+	if last < len(src) {
+		segments = append(segments, segment{text: src[last:]})
+	}
+	return segments
+}
+
+// printfArgs assigns a printf argument position to every field referenced by the templates of mt.
+// The plural count is the first argument of plural messages,
+// the other fields are numbered in the order they first appear.
+func printfArgs(mt *i18n.MessageTemplate) map[string]int {
+	args := map[string]int{}
+	if len(mt.PluralTemplates) > 1 {
+		args[pluralCountField] = 1
+	}
+	re := fieldActionRegexp(mt.LeftDelim, mt.RightDelim)
+	for _, form := range pluralForms {
+		t := mt.PluralTemplates[form]
+		if t == nil {
+			continue
+		}
+		for _, seg := range splitFields(t.Src, re) {
+			if seg.field != "" && args[seg.field] == 0 {
+				args[seg.field] = len(args) + 1
+			}
+		}
+	}
+	return args
+}
+
+// printfVerb returns the printf verb used for field, strVerb is the verb for strings.
+func printfVerb(field, strVerb string) string {
+	if field == pluralCountField {
+		return "d"
+	}
+	return strVerb
+}
+
+// printfSpecRegexp matches printf format specifiers as used by Android and iOS, e.g. %1$s, %@ and %ld.
+var printfSpecRegexp = regexp.MustCompile(`%(?:%|(?:([1-9][0-9]*)\$)?[-#+0,]*[0-9]*(?:\.[0-9]+)?(?:hh|h|ll|l|q|z|t|j)?([@dDiuUxXoOfFeEgGcCsSaAp]))`)
+
+// fromPrintf converts the printf format string s to a template.
+// Specifiers are replaced by {{.Name}} where Name is names[position],
+// or ArgN if the position has no name.
+func fromPrintf(s string, names map[int]string) string {
+	next := 0
+	return printfSpecRegexp.ReplaceAllStringFunc(s, func(spec string) string {
+		if spec == "%%" {
+			return "%"
+		}
+		m := printfSpecRegexp.FindStringSubmatch(spec)
+		var pos int
+		if m[1] != "" {
+			pos, _ = strconv.Atoi(m[1])
+		} else {
+			next++
+			pos = next
+		}
+		name := names[pos]
+		if name == "" {
+			name = fmt.Sprintf("Arg%d", pos)
+		}
+		return "{{." + name + "}}"
+	})
+}
+
+// sortedIDs returns the ids of messageTemplates in alphabetical order.
+func sortedIDs(messageTemplates map[string]*i18n.MessageTemplate) []string {
+	ids := make([]string, 0, len(messageTemplates))
+	for id := range messageTemplates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"errors"
+
+	"github.com/hollson/i18n/internal/plural"
+	"golang.org/x/text/language"
+)
+
+// LocalizableError is an error whose message can be localized,
+// e.g. an error of the business layer that the HTTP layer localizes for the client.
+// Error returns the text of the message in the default language,
+// Localize the text in the language of a Localizer.
+type LocalizableError struct {
+	// Message is the message of the error.
+	Message *Message
+
+	// TemplateData is the template data of the message.
+	TemplateData interface{}
+
+	// PluralCount determines the plural form of the message, see LocalizeConfig.
+	PluralCount interface{}
+
+	// Err is the cause of the error, if any.
+	Err error
+}
+
+// NewError returns an error with the message msg and its template data.
+func NewError(msg *Message, templateData interface{}) *LocalizableError {
+	return &LocalizableError{Message: msg, TemplateData: templateData}
+}
+
+// NewErrorText returns an error with the message of the given id and text in the default language.
+// The extract command extracts the message like a Message literal.
+func NewErrorText(id, text string, templateData interface{}) *LocalizableError {
+	return NewError(&Message{ID: id, Other: text}, templateData)
+}
+
+// WrapError returns an error with the message msg caused by err.
+func WrapError(err error, msg *Message, templateData interface{}) *LocalizableError {
+	return &LocalizableError{Message: msg, TemplateData: templateData, Err: err}
+}
+
+// WithPluralCount returns a copy of e that uses the plural form of count.
+func (e *LocalizableError) WithPluralCount(count interface{}) *LocalizableError {
+	copied := *e
+	copied.PluralCount = count
+	return &copied
+}
+
+// Error returns the text of the message in the default language, followed by the cause if any.
+// The plural form is chosen by the plural rules of English.
+func (e *LocalizableError) Error() string {
+	text := e.defaultText()
+	if e.Err != nil {
+		return text + ": " + e.Err.Error()
+	}
+	return text
+}
+
+func (e *LocalizableError) defaultText() string {
+	if e.Message == nil {
+		return ""
+	}
+	template := NewMessageTemplate(e.Message)
+	if template == nil {
+		return e.Message.ID
+	}
+	pluralForm := plural.Other
+	if e.PluralCount != nil {
+		if operands, err := plural.NewOperands(e.PluralCount); err == nil {
+			pluralForm = plural.DefaultRules().Rule(language.English).PluralFormFunc(operands)
+		}
+	}
+	text, err := template.Execute(pluralForm, e.templateData(), nil)
+	if err != nil && pluralForm != plural.Other {
+		text, err = template.Execute(plural.Other, e.templateData(), nil)
+	}
+	if err != nil {
+		return e.Message.ID
+	}
+	return text
+}
+
+func (e *LocalizableError) templateData() interface{} {
+	if e.TemplateData == nil && e.PluralCount != nil {
+		return map[string]interface{}{
+			"PluralCount": e.PluralCount,
+		}
+	}
+	return e.TemplateData
+}
+
+// Unwrap returns the cause of the error.
+func (e *LocalizableError) Unwrap() error {
+	return e.Err
+}
+
+// Is tells whether target is a LocalizableError with a message of the same id,
+// so that errors.Is(err, i18n.NewError(msg, nil)) checks for the message msg.
+func (e *LocalizableError) Is(target error) bool {
+	t, ok := target.(*LocalizableError)
+	return ok && e.Message != nil && t.Message != nil && e.Message.ID == t.Message.ID
+}
+
+// Localize returns the text of the message in the language of l, without the cause.
+func (e *LocalizableError) Localize(l *Localizer) (string, error) {
+	return l.Localize(e.localizeConfig())
+}
+
+func (e *LocalizableError) localizeConfig() *LocalizeConfig {
+	return &LocalizeConfig{
+		DefaultMessage: e.Message,
+		TemplateData:   e.TemplateData,
+		PluralCount:    e.PluralCount,
+	}
+}
+
+// LocalizeError returns the localized message of the first LocalizableError in the chain of err,
+// or the text of err if there is none.
+func (l *Localizer) LocalizeError(err error) (string, error) {
+	var le *LocalizableError
+	if !errors.As(err, &le) {
+		return err.Error(), nil
+	}
+	return le.Localize(l)
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/hollson/i18n/internal/plural"
+	"golang.org/x/text/language"
+)
+
+// I18nextError is a message that cannot be expressed in i18next JSON and is left out of the export.
+type I18nextError struct {
+	MessageID  string
+	PluralForm PluralForm
+	Err        error
+}
+
+func (e *I18nextError) Error() string {
+	if e.PluralForm == "" {
+		return fmt.Sprintf("message %q cannot be exported to i18next: %s", e.MessageID, e.Err)
+	}
+	return fmt.Sprintf("message %q (%s) cannot be exported to i18next: %s", e.MessageID, e.PluralForm, e.Err)
+}
+
+// ExportI18next returns the messages of tag in the i18next JSON format (v4), to be marshaled with encoding/json.
+//
+// The ids are split into nested keys at ".", and the plural forms of a message with any besides "other"
+// are exported with the suffixes of i18next, e.g. "unread_one" and "unread_other".
+// Field references like {{.Name}} become i18next interpolations like {{Name}}, and {{.PluralCount}} becomes {{count}}.
+// Messages with other template actions, e.g. functions, pipelines or conditions, cannot be expressed
+// and are returned as errors instead.
+func (b *Bundle) ExportI18next(tag language.Tag) (map[string]interface{}, []*I18nextError) {
+	templates := b.messageTemplates[tag]
+	ids := make([]string, 0, len(templates))
+	for id, template := range templates {
+		if template != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	root := map[string]interface{}{}
+	var errs []*I18nextError
+	for _, id := range ids {
+		texts, textErr := i18nextTexts(templates[id])
+		if textErr != nil {
+			errs = append(errs, textErr)
+			continue
+		}
+		keys := strings.Split(id, ".")
+		namespace, err := i18nextNamespace(root, keys[:len(keys)-1])
+		if err == nil {
+			err = i18nextSet(namespace, keys[len(keys)-1], texts)
+		}
+		if err != nil {
+			errs = append(errs, &I18nextError{MessageID: id, Err: err})
+		}
+	}
+	return root, errs
+}
+
+// i18nextTexts returns the i18next texts of the message template by key suffix.
+func i18nextTexts(mt *MessageTemplate) (map[string]string, *I18nextError) {
+	texts := make(map[string]string, len(mt.PluralTemplates))
+	for pluralForm, t := range mt.PluralTemplates {
+		text, err := i18nextText(t.Src, t.LeftDelim, t.RightDelim)
+		if err != nil {
+			return nil, &I18nextError{MessageID: mt.ID, PluralForm: pluralForm, Err: err}
+		}
+		suffix := "_" + string(pluralForm)
+		if len(mt.PluralTemplates) == 1 && pluralForm == plural.Other {
+			suffix = ""
+		}
+		texts[suffix] = text
+	}
+	return texts, nil
+}
+
+// i18nextText converts the template src to an i18next text.
+func i18nextText(src, leftDelim, rightDelim string) (string, error) {
+	t, err := template.New("").Delims(leftDelim, rightDelim).Parse(src)
+	if err != nil {
+		return "", err
+	}
+	if t.Tree == nil {
+		return src, nil
+	}
+	var b strings.Builder
+	for _, node := range t.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			// i18next would interpolate or nest the text.
+			if text := string(n.Text); strings.Contains(text, "{{") || strings.Contains(text, "$t(") {
+				return "", fmt.Errorf("text %q has i18next syntax", text)
+			}
+			b.Write(n.Text)
+		case *parse.ActionNode:
+			name, ok := i18nextVariable(n.Pipe)
+			if !ok {
+				return "", fmt.Errorf("action %s is not a field reference", n)
+			}
+			b.WriteString("{{" + name + "}}")
+		default:
+			return "", fmt.Errorf("action %s is not a field reference", n)
+		}
+	}
+	return b.String(), nil
+}
+
+// i18nextVariable returns the i18next variable of a pipeline that is a field reference like .Name or .User.Name.
+func i18nextVariable(pipe *parse.PipeNode) (string, bool) {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return "", false
+	}
+	name := strings.Join(field.Ident, ".")
+	if name == "PluralCount" {
+		// i18next selects the plural form by the count variable.
+		name = "count"
+	}
+	return name, true
+}
+
+// i18nextNamespace returns the nested object of root at keys, creating it if needed.
+func i18nextNamespace(root map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	namespace := root
+	for i, key := range keys {
+		switch v := namespace[key].(type) {
+		case nil:
+			nested := map[string]interface{}{}
+			namespace[key] = nested
+			namespace = nested
+		case map[string]interface{}:
+			namespace = v
+		default:
+			return nil, fmt.Errorf("namespace %q is also a message", strings.Join(keys[:i+1], "."))
+		}
+	}
+	return namespace, nil
+}
+
+// i18nextSet sets the texts of the message key in namespace.
+func i18nextSet(namespace map[string]interface{}, key string, texts map[string]string) error {
+	for suffix := range texts {
+		if _, ok := namespace[key+suffix]; ok {
+			return fmt.Errorf("key %q is already used", key+suffix)
+		}
+	}
+	for suffix, text := range texts {
+		namespace[key+suffix] = text
+	}
+	return nil
+}
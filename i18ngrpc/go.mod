@@ -0,0 +1,22 @@
+module github.com/hollson/i18n/i18ngrpc
+
+go 1.21
+
+require (
+	github.com/hollson/i18n v0.0.0-00010101000000-000000000000
+	golang.org/x/text v0.16.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117
+	google.golang.org/grpc v1.66.2
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
+
+// Development only: github.com/hollson/i18n is resolved from this tree, which is why it is
+// required at the zero pseudo-version above. Before tagging a release of i18ngrpc
+// (i18ngrpc/vX.Y.Z), require a published tag of github.com/hollson/i18n and drop this replace,
+// since replace directives of dependencies are ignored by the modules that use them.
+replace github.com/hollson/i18n => ../
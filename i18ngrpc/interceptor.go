@@ -0,0 +1,47 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18ngrpc
+
+import (
+	"context"
+
+	"github.com/hollson/i18n"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns an interceptor that puts the Localizer of each request in the context of the handler,
+// see NewLocalizer and FromContext, and localizes the errors the handler returns, see LocalizeError.
+func UnaryServerInterceptor(bundle *i18n.Bundle) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		l := NewLocalizer(ctx, bundle)
+		resp, err := handler(NewContext(ctx, l), req)
+		if err != nil {
+			return resp, LocalizeError(l, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is the stream counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(bundle *i18n.Bundle) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		l := NewLocalizer(ss.Context(), bundle)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: NewContext(ss.Context(), l)})
+		if err != nil {
+			return LocalizeError(l, err)
+		}
+		return nil
+	}
+}
+
+// serverStream is a ServerStream whose context carries a Localizer.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18ngrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var notFound = &i18n.Message{ID: "NotFound", Other: "Service {{.Service}} not found"}
+
+// healthServer fails every check with a localizable error.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	t *testing.T
+}
+
+func (hs *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if _, ok := FromContext(ctx); !ok {
+		hs.t.Errorf("no Localizer in the context of the unary handler")
+	}
+	return nil, i18n.WrapError(status.Error(codes.NotFound, "not found"), notFound, map[string]string{"Service": req.Service})
+}
+
+func (hs *healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	l, ok := FromContext(stream.Context())
+	if !ok {
+		hs.t.Errorf("no Localizer in the context of the stream handler")
+		return status.Error(codes.Internal, "no localizer")
+	}
+	return Error(l, codes.NotFound, notFound, map[string]string{"Service": req.Service})
+}
+
+func newHealthClient(t *testing.T, bundle *i18n.Bundle) grpc_health_v1.HealthClient {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(bundle)),
+		grpc.StreamInterceptor(StreamServerInterceptor(bundle)),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{t: t})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{ID: "NotFound", Other: "Сервис {{.Service}} не найден"})
+	client := newHealthClient(t, bundle)
+
+	tests := []struct {
+		acceptLanguage string
+		message        string
+		locale         string
+	}{
+		{"ru", "Сервис db не найден", "ru"},
+		{"fr, ru;q=0.5", "Сервис db не найден", "ru"},
+		{"fr", "Service db not found", "en"},
+		{"", "Service db not found", "en"},
+	}
+	for _, test := range tests {
+		ctx := context.Background()
+		if test.acceptLanguage != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, AcceptLanguageKey, test.acceptLanguage)
+		}
+		req := &grpc_health_v1.HealthCheckRequest{Service: "db"}
+
+		_, err := client.Check(ctx, req)
+		checkStatus(t, "Check "+test.acceptLanguage, err, test.message, test.locale)
+
+		stream, err := client.Watch(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		checkStatus(t, "Watch "+test.acceptLanguage, err, test.message, test.locale)
+	}
+}
+
+// checkStatus checks that err is a NotFound status with the localized message and its LocalizedMessage detail.
+func checkStatus(t *testing.T, name string, err error, message, locale string) {
+	t.Helper()
+	s, ok := status.FromError(err)
+	if !ok {
+		t.Errorf("%s: %v is not a status error", name, err)
+		return
+	}
+	if s.Code() != codes.NotFound || s.Message() != message {
+		t.Errorf("%s: status is %s %q; expected %s %q", name, s.Code(), s.Message(), codes.NotFound, message)
+	}
+	var details []*errdetails.LocalizedMessage
+	for _, detail := range s.Details() {
+		if lm, ok := detail.(*errdetails.LocalizedMessage); ok {
+			details = append(details, lm)
+		}
+	}
+	if len(details) != 1 || details[0].Locale != locale || details[0].Message != message {
+		t.Errorf("%s: LocalizedMessage details are %v; expected one with locale %q and message %q", name, details, locale, message)
+	}
+}
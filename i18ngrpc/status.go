@@ -0,0 +1,112 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package i18ngrpc localizes the status of gRPC services.
+//
+// The languages of a request are read from the accept-language metadata,
+// and localized messages are returned as google.rpc.LocalizedMessage details of the status,
+// so that clients can show them as they are.
+package i18ngrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hollson/i18n"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AcceptLanguageKey is the metadata key of the language preferences of a request.
+const AcceptLanguageKey = "accept-language"
+
+// NewLocalizer returns a Localizer of the bundle for the languages in the accept-language metadata of the incoming context.
+// The default language of the bundle is used if there is none.
+func NewLocalizer(ctx context.Context, bundle *i18n.Bundle) *i18n.Localizer {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.NewLocalizer(bundle, md.Get(AcceptLanguageKey)...)
+}
+
+type localizerKey struct{}
+
+// NewContext returns a copy of ctx that carries the Localizer l.
+func NewContext(ctx context.Context, l *i18n.Localizer) context.Context {
+	return context.WithValue(ctx, localizerKey{}, l)
+}
+
+// FromContext returns the Localizer carried by ctx, e.g. the one the interceptors put in the context of a handler.
+func FromContext(ctx context.Context) (*i18n.Localizer, bool) {
+	l, ok := ctx.Value(localizerKey{}).(*i18n.Localizer)
+	return l, ok
+}
+
+// Status returns a status with the code c whose message is localized by l.
+// The localized message is attached as a LocalizedMessage detail with the language tag it was resolved in.
+// The message in the default language is used if it is not translated to the languages of l.
+func Status(l *i18n.Localizer, c codes.Code, lc *i18n.LocalizeConfig) (*status.Status, error) {
+	return localizedStatus(status.New(c, ""), l, lc)
+}
+
+// Error returns an error with the code c and the message msg localized by l, see Status.
+func Error(l *i18n.Localizer, c codes.Code, msg *i18n.Message, templateData interface{}) error {
+	s, err := Status(l, c, &i18n.LocalizeConfig{DefaultMessage: msg, TemplateData: templateData})
+	if err != nil {
+		return err
+	}
+	return s.Err()
+}
+
+// LocalizeError returns a status error of the first LocalizableError in the chain of err, localized by l.
+// The code and details are those of the status of its cause, if any, and the code is Unknown otherwise.
+// err is returned as it is if there is no LocalizableError, or if it is already localized.
+func LocalizeError(l *i18n.Localizer, err error) error {
+	var le *i18n.LocalizableError
+	if !errors.As(err, &le) || localized(err) {
+		return err
+	}
+	s, ok := status.FromError(le.Err)
+	if le.Err == nil || !ok {
+		s = status.New(codes.Unknown, "")
+	}
+	ls, lerr := localizedStatus(s, l, &i18n.LocalizeConfig{
+		DefaultMessage: le.Message,
+		TemplateData:   le.TemplateData,
+		PluralCount:    le.PluralCount,
+	})
+	if lerr != nil {
+		return status.Error(s.Code(), err.Error())
+	}
+	return ls.Err()
+}
+
+// localizedStatus returns a copy of s with the message of lc localized by l and its LocalizedMessage detail.
+func localizedStatus(s *status.Status, l *i18n.Localizer, lc *i18n.LocalizeConfig) (*status.Status, error) {
+	text, tag, err := l.LocalizeWithTag(lc)
+	if text == "" && err != nil {
+		return nil, err
+	}
+	p := s.Proto()
+	p.Message = text
+	ls := status.FromProto(p)
+	return ls.WithDetails(&errdetails.LocalizedMessage{
+		Locale:  tag.String(),
+		Message: text,
+	})
+}
+
+// localized tells whether err is a status error with a LocalizedMessage detail.
+func localized(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, detail := range s.Details() {
+		if _, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return true
+		}
+	}
+	return false
+}
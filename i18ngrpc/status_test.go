@@ -0,0 +1,52 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18ngrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLocalizeError(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Russian, &i18n.Message{ID: "NotFound", Other: "Сервис {{.Service}} не найден"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AcceptLanguageKey, "ru"))
+	l := NewLocalizer(ctx, bundle)
+	data := map[string]string{"Service": "db"}
+
+	plain := errors.New("plain")
+	if err := LocalizeError(l, plain); err != plain {
+		t.Errorf("LocalizeError changed an error without a LocalizableError to %v", err)
+	}
+
+	wrapped := fmt.Errorf("check: %w", i18n.NewError(notFound, data))
+	checkCode(t, "without cause", LocalizeError(l, wrapped), codes.Unknown, "Сервис db не найден")
+
+	withCause := i18n.WrapError(status.Error(codes.NotFound, "not found"), notFound, data)
+	localized := LocalizeError(l, withCause)
+	checkCode(t, "with status cause", localized, codes.NotFound, "Сервис db не найден")
+
+	// An error that is already localized, e.g. by another interceptor, is kept as it is.
+	joined := fmt.Errorf("%w (%w)", localized, withCause)
+	if err := LocalizeError(NewLocalizer(context.Background(), bundle), joined); err != joined {
+		t.Errorf("LocalizeError localized an error that was already localized to %v", err)
+	}
+}
+
+func checkCode(t *testing.T, name string, err error, code codes.Code, message string) {
+	t.Helper()
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != code || s.Message() != message {
+		t.Errorf("%s: error is %v; expected status %s %q", name, err, code, message)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package i18nhttp serves the messages of a bundle over HTTP,
+// so that web and mobile clients can fetch up-to-date strings from the service.
+package i18nhttp
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+// Response is the JSON body of the Handler.
+type Response struct {
+	// Tag is the language tag of the bundle that best matches the request.
+	Tag language.Tag `json:"tag"`
+
+	// Messages are the messages by id, see Localizer.LocalizeAll.
+	Messages map[string]*i18n.LocalizedMessage `json:"messages"`
+}
+
+// Handler serves the messages of a bundle as JSON, in the language of the request.
+//
+// The language is negotiated with the languages of the bundle from the lang query parameter
+// and the Accept-Language header, and each message falls back to the default language like Localizer.Localize.
+// The prefix query parameter filters the messages by dotted namespace, e.g. ?prefix=page.home.
+//
+// Responses have a strong ETag computed from the language tag, the prefix and the resolved messages,
+// and requests whose If-None-Match matches it are answered with 304 Not Modified without building the body.
+type Handler struct {
+	bundle *i18n.Bundle
+}
+
+// NewHandler returns a Handler that serves the messages of bundle.
+// The bundle must not be modified while the Handler serves it.
+func NewHandler(bundle *i18n.Bundle) *Handler {
+	return &Handler{bundle: bundle}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	localizer := i18n.NewLocalizer(h.bundle, query.Get("lang"), r.Header.Get("Accept-Language"))
+	tag := localizer.LanguageTag()
+	messages := localizer.LocalizeAll(prefix)
+	etag := entityTag(tag, prefix, messages)
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Set("Content-Language", tag.String())
+	header.Add("Vary", "Accept-Language")
+	if noneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	body, err := json.Marshal(&Response{Tag: tag, Messages: messages})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// entityTag returns the strong ETag of the response with the messages of tag and prefix.
+// It hashes the messages by id instead of the marshaled body, so that matching requests are not marshaled.
+func entityTag(tag language.Tag, prefix string, messages map[string]*i18n.LocalizedMessage) string {
+	ids := make([]string, 0, len(messages))
+	for id := range messages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	// Fields are prefixed with their length so that they cannot run into each other.
+	write := func(fields ...string) {
+		for _, field := range fields {
+			fmt.Fprintf(h, "%d:%s", len(field), field)
+		}
+	}
+	write(tag.String(), prefix)
+	for _, id := range ids {
+		lm := messages[id]
+		write(id, lm.Tag.String(), lm.Text, lm.LeftDelim, lm.RightDelim, strconv.Itoa(len(lm.Templates)))
+		forms := make([]string, 0, len(lm.Templates))
+		for form := range lm.Templates {
+			forms = append(forms, string(form))
+		}
+		sort.Strings(forms)
+		for _, form := range forms {
+			write(form, lm.Templates[i18n.PluralForm(form)])
+		}
+	}
+	return fmt.Sprintf(`"%x"`, h.Sum(nil))
+}
+
+// noneMatch tells whether the If-None-Match header matches etag.
+// Like for GET and HEAD requests in RFC 7232, weak entity tags match the strong etag of the same value.
+func noneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
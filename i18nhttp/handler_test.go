@@ -0,0 +1,177 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18nhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+func newBundle() *i18n.Bundle {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.English,
+		&i18n.Message{ID: "page.home.title", Other: "Home"},
+		&i18n.Message{ID: "page.home.intro", Other: "Hello {{.Name}}"},
+		&i18n.Message{ID: "page.about", Other: "About"},
+		&i18n.Message{ID: "menu", Other: "Menu"},
+	)
+	bundle.MustAddMessages(language.Russian,
+		&i18n.Message{ID: "page.home.title", Other: "Главная"},
+		&i18n.Message{ID: "menu", Other: "Меню"},
+	)
+	return bundle
+}
+
+// get serves a GET request to the target with the headers, which are pairs of keys and values.
+func get(h http.Handler, target string, headers ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) *Response {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status is %d; expected %d", w.Code, http.StatusOK)
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid body %s: %s", w.Body, err)
+	}
+	return &resp
+}
+
+func TestHandlerNegotiation(t *testing.T) {
+	h := NewHandler(newBundle())
+	tests := []struct {
+		target         string
+		acceptLanguage string
+		tag            language.Tag
+		title          string
+	}{
+		{"/", "", language.English, "Home"},
+		{"/", "ru", language.Russian, "Главная"},
+		{"/", "fr, ru;q=0.8", language.Russian, "Главная"},
+		{"/?lang=ru", "en", language.Russian, "Главная"},
+		{"/?lang=fr", "", language.English, "Home"},
+	}
+	for _, test := range tests {
+		w := get(h, test.target, "Accept-Language", test.acceptLanguage)
+		resp := decode(t, w)
+		if resp.Tag != test.tag {
+			t.Errorf("%s %q: tag is %s; expected %s", test.target, test.acceptLanguage, resp.Tag, test.tag)
+		}
+		if lang := w.Header().Get("Content-Language"); lang != test.tag.String() {
+			t.Errorf("%s %q: Content-Language is %q; expected %q", test.target, test.acceptLanguage, lang, test.tag)
+		}
+		if vary := w.Header().Values("Vary"); len(vary) != 1 || vary[0] != "Accept-Language" {
+			t.Errorf("%s %q: Vary is %q; expected Accept-Language", test.target, test.acceptLanguage, vary)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+			t.Errorf("%s %q: Content-Type is %q", test.target, test.acceptLanguage, ct)
+		}
+		if title := resp.Messages["page.home.title"]; title == nil || title.Text != test.title {
+			t.Errorf("%s %q: title is %+v; expected %q", test.target, test.acceptLanguage, title, test.title)
+		}
+	}
+
+	// Messages that are not translated fall back to the default language.
+	resp := decode(t, get(h, "/?lang=ru"))
+	if about := resp.Messages["page.about"]; about == nil || about.Text != "About" || about.Tag != language.English {
+		t.Errorf("untranslated message is %+v; expected the English text", about)
+	}
+	intro := resp.Messages["page.home.intro"]
+	if intro == nil || intro.Text != "" || intro.Templates[i18n.PluralForm("other")] != "Hello {{.Name}}" {
+		t.Errorf("message with template actions is %+v; expected its template", intro)
+	}
+}
+
+func TestHandlerPrefix(t *testing.T) {
+	h := NewHandler(newBundle())
+	tests := map[string][]string{
+		"/":                      {"menu", "page.about", "page.home.intro", "page.home.title"},
+		"/?prefix=page":          {"page.about", "page.home.intro", "page.home.title"},
+		"/?prefix=page.home":     {"page.home.intro", "page.home.title"},
+		"/?prefix=page.home.tit": nil,
+		"/?prefix=menu":          {"menu"},
+	}
+	for target, expected := range tests {
+		resp := decode(t, get(h, target))
+		ids := make([]string, 0, len(resp.Messages))
+		for id := range resp.Messages {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		if strings.Join(ids, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: messages are %q; expected %q", target, ids, expected)
+		}
+	}
+}
+
+func TestHandlerETag(t *testing.T) {
+	h := NewHandler(newBundle())
+	w := get(h, "/?lang=ru")
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) || len(etag) < 3 {
+		t.Fatalf("ETag %q is not a strong entity tag", etag)
+	}
+	if again := get(h, "/?lang=ru").Header().Get("ETag"); again != etag {
+		t.Errorf("ETag changed from %q to %q for the same messages", etag, again)
+	}
+	for _, target := range []string{"/", "/?lang=ru&prefix=page", "/?lang=ru&prefix=menu"} {
+		if other := get(h, target).Header().Get("ETag"); other == etag {
+			t.Errorf("%s has the ETag of /?lang=ru", target)
+		}
+	}
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := get(h, "/?lang=ru", "If-None-Match", ifNoneMatch)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("If-None-Match %s: status is %d; expected %d", ifNoneMatch, w.Code, http.StatusNotModified)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: 304 response has a body", ifNoneMatch)
+		}
+		if w.Header().Get("ETag") != etag || w.Header().Get("Vary") != "Accept-Language" {
+			t.Errorf("If-None-Match %s: 304 response lacks the ETag or Vary headers", ifNoneMatch)
+		}
+	}
+	if w := get(h, "/?lang=ru", "If-None-Match", `"other"`); w.Code != http.StatusOK {
+		t.Errorf("If-None-Match of another ETag: status is %d; expected %d", w.Code, http.StatusOK)
+	}
+	if w := get(h, "/", "If-None-Match", etag); w.Code != http.StatusOK {
+		t.Errorf("If-None-Match of the ETag of another language: status is %d; expected %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestHandlerMethods(t *testing.T) {
+	h := NewHandler(newBundle())
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/?lang=ru", nil))
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("HEAD: status is %d with %d bytes; expected %d without body", w.Code, w.Body.Len(), http.StatusOK)
+	}
+	if w.Header().Get("Content-Length") == "" || w.Header().Get("ETag") != get(h, "/?lang=ru").Header().Get("ETag") {
+		t.Errorf("HEAD: headers %v differ from GET", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST: status is %d with Allow %q; expected %d with GET, HEAD", w.Code, w.Header().Get("Allow"), http.StatusMethodNotAllowed)
+	}
+}
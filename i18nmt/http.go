@@ -0,0 +1,92 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18nmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// HTTPTranslator translates text by posting it as JSON to an HTTP endpoint:
+//
+//	{"source": "en", "target": "ru", "id": "PersonCats", "description": "...", "context": "...", "form": "few", "text": "..."}
+//
+// and reading the translation from the JSON response:
+//
+//	{"text": "..."}
+type HTTPTranslator struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPTranslator returns an HTTPTranslator that posts to url with client,
+// or with http.DefaultClient if client is nil.
+func NewHTTPTranslator(url string, client *http.Client) *HTTPTranslator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPTranslator{url: url, client: client}
+}
+
+type httpRequest struct {
+	Source      string `json:"source"`
+	Target      string `json:"target"`
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context,omitempty"`
+	Form        string `json:"form"`
+	Text        string `json:"text"`
+}
+
+type httpResponse struct {
+	Text string `json:"text"`
+}
+
+// Translate implements Translator.
+func (ht *HTTPTranslator) Translate(ctx context.Context, req *Request) (string, error) {
+	body, err := json.Marshal(&httpRequest{
+		Source:      req.Source.String(),
+		Target:      req.Target.String(),
+		ID:          req.ID,
+		Description: req.Description,
+		Context:     req.Context,
+		Form:        string(req.Form),
+		Text:        req.Text,
+	})
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, ht.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := ht.client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(content)))
+	}
+	var res httpResponse
+	if err := json.Unmarshal(content, &res); err != nil {
+		return "", fmt.Errorf("invalid response: %s", err)
+	}
+	if res.Text == "" {
+		return "", errors.New("empty translation")
+	}
+	return res.Text, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package i18nmt machine translates messages to pre-fill translations before human review,
+// as done by the translate command of i18n_cli.
+package i18nmt
+
+import (
+	"context"
+
+	"github.com/hollson/i18n"
+	"golang.org/x/text/language"
+)
+
+// Translator translates the text of messages, e.g. with a machine translation engine.
+type Translator interface {
+	// Translate returns the translation of the text of req to req.Target.
+	Translate(ctx context.Context, req *Request) (string, error)
+}
+
+// Request is a plural form of a message to translate.
+type Request struct {
+	Source      language.Tag
+	Target      language.Tag
+	ID          string
+	Description string
+	Context     string
+	Form        i18n.PluralForm
+
+	// Text is the source text. i18n_cli replaces its template actions with placeholders like {0},
+	// which the translation must keep.
+	Text string
+}
@@ -1,6 +1,12 @@
 #!/bin/sh
+# CLDR_VERSION is the CLDR release that plurals.xml (and ordinals.xml, if present) was copied from.
+CLDR_VERSION=47
 OUT=..
-go build && ./codegen -cout $OUT/rule_gen.go -tout $OUT/rule_gen_test.go && \
+IN=plurals.xml
+if [ -f ordinals.xml ]; then
+    IN=$IN,ordinals.xml
+fi
+go build && ./codegen -cldr $CLDR_VERSION -i $IN -cout $OUT/rule_gen.go -tout $OUT/rule_gen_test.go && \
     gofmt -w=true $OUT/rule_gen.go && \
     gofmt -w=true $OUT/rule_gen_test.go && \
     rm codegen
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"text/template"
 )
 
@@ -17,33 +18,64 @@ Options:
 
 `
 
+// generatorData is the data passed to the code and test templates.
+type generatorData struct {
+	CLDRVersion string
+	Cardinal    []PluralGroup
+	Ordinal     []PluralGroup
+}
+
 func main() {
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, usage, os.Args[0])
 		flag.PrintDefaults()
 	}
-	var in, cout, tout string
-	flag.StringVar(&in, "i", "plurals.xml", "the input XML file containing CLDR plural rules")
+	var in, cldrVersion, cout, tout string
+	flag.StringVar(&in, "i", "plurals.xml", "a comma separated list of input XML files containing CLDR plural rules (e.g. plurals.xml,ordinals.xml)")
+	flag.StringVar(&cldrVersion, "cldr", "", "the CLDR version of the input files (defaults to the cldrVersion attribute of the version element)")
 	flag.StringVar(&cout, "cout", "", "the code output file")
 	flag.StringVar(&tout, "tout", "", "the test output file")
 	flag.BoolVar(&verbose, "v", false, "verbose output")
 	flag.Parse()
 
-	buf, err := ioutil.ReadFile(in)
-	if err != nil {
-		fatalf("failed to read file: %s", err)
-	}
+	data := generatorData{CLDRVersion: cldrVersion}
+	for _, path := range strings.Split(in, ",") {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			fatalf("failed to read file: %s", err)
+		}
 
-	var data SupplementalData
-	if err := xml.Unmarshal(buf, &data); err != nil {
-		fatalf("failed to unmarshal xml: %s", err)
-	}
+		var sd SupplementalData
+		if err := xml.Unmarshal(buf, &sd); err != nil {
+			fatalf("failed to unmarshal xml: %s", err)
+		}
+		if data.CLDRVersion == "" {
+			data.CLDRVersion = sd.Version.CLDRVersion
+		}
 
-	count := 0
-	for _, pg := range data.PluralGroups {
-		count += len(pg.SplitLocales())
+		for _, plurals := range sd.Plurals {
+			count := 0
+			for _, pg := range plurals.PluralGroups {
+				count += len(pg.SplitLocales())
+			}
+			switch plurals.Type {
+			case "", "cardinal":
+				data.Cardinal = append(data.Cardinal, plurals.PluralGroups...)
+			case "ordinal":
+				data.Ordinal = append(data.Ordinal, plurals.PluralGroups...)
+			default:
+				fatalf("unknown plurals type %q in %s", plurals.Type, path)
+			}
+			infof("parsed %d %s locales from %s", count, plurals.Type, path)
+		}
 	}
-	infof("parsed %d locales", count)
+	if data.CLDRVersion == "" {
+		fatalf("unknown CLDR version (use -cldr)")
+	}
+	if len(data.Cardinal) == 0 {
+		fatalf("no cardinal plural rules found in %s", in)
+	}
+	verbosef("CLDR version %s", data.CLDRVersion)
 
 	if cout != "" {
 		file := openWritableFile(cout)
@@ -80,11 +112,24 @@ var codeTemplate = template.Must(template.New("rule").Parse(`// This file is gen
 
 package plural
 
+// CLDRVersion is the version of the CLDR data the rules were generated from.
+const CLDRVersion = {{printf "%q" .CLDRVersion}}
+
 // DefaultRules returns a map of Rules generated from CLDR language data.
 func DefaultRules() Rules {
 	rules := Rules{}
-
-{{range .PluralGroups}}
+{{template "groups" .Cardinal}}
+	return rules
+}
+{{if .Ordinal}}
+// DefaultOrdinalRules returns a map of ordinal Rules generated from CLDR language data.
+func DefaultOrdinalRules() Rules {
+	rules := Rules{}
+{{template "groups" .Ordinal}}
+	return rules
+}
+{{end}}
+{{- define "groups"}}{{range .}}
 	addPluralRules(rules, {{printf "%#v" .SplitLocales}}, &Rule{
 		PluralForms: newPluralFormSet({{range $i, $e := .PluralRules}}{{if $i}}, {{end}}{{$e.CountTitle}}{{end}}),
 		PluralFormFunc: func(ops *Operands) Form { {{range .PluralRules}}{{if .GoCondition}}
@@ -95,31 +140,74 @@ func DefaultRules() Rules {
 			return Other
 		},
 	}){{end}}
-
-	return rules
-}
-`))
+{{end}}`))
 
 var testTemplate = template.Must(template.New("rule").Parse(`// This file is generated by i18n/plural/codegen/generate.sh; DO NOT EDIT
 
 package plural
 
-import "testing"
-
-{{range .PluralGroups}}
-func Test{{.Name}}(t *testing.T) {
-	var tests []pluralFormTest
-	{{range .PluralRules}}
-	{{if .IntegerExamples}}tests = appendIntegerTests(tests, {{.CountTitle}}, {{printf "%#v" .IntegerExamples}}){{end}}
-	{{if .DecimalExamples}}tests = appendDecimalTests(tests, {{.CountTitle}}, {{printf "%#v" .DecimalExamples}}){{end}}
-	{{end}}
-	locales := {{printf "%#v" .SplitLocales}}
-	for _, locale := range locales {
-	  runTests(t, locale, tests)
-  }
+import (
+	"strconv"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+type pluralSamples struct {
+	locales []string
+	form    Form
+	integer []string
+	decimal []string
+}
+
+// runSampleTests checks that every @integer and @decimal sample of CLDR {{.CLDRVersion}} selects its plural form.
+func runSampleTests(t *testing.T, rules Rules, tests []pluralSamples) {
+	for _, test := range tests {
+		for _, locale := range test.locales {
+			if locale == "root" {
+				continue
+			}
+			rule := rules.Rule(language.MustParse(locale))
+			if rule == nil {
+				t.Errorf("could not find plural rule for locale %s", locale)
+				continue
+			}
+			var nums []interface{}
+			for _, sample := range test.integer {
+				nums = append(nums, sample)
+				if i, err := strconv.ParseInt(sample, 10, 64); err == nil {
+					nums = append(nums, i)
+				}
+			}
+			for _, sample := range test.decimal {
+				nums = append(nums, sample)
+			}
+			for _, num := range nums {
+				ops, err := NewOperands(num)
+				if err != nil {
+					t.Errorf("%s: NewOperands(%#v) errored with %s", locale, num, err)
+					continue
+				}
+				if form := rule.PluralFormFunc(ops); form != test.form {
+					t.Errorf("%s: PluralFormFunc(%#v) returned %q; expected %q", locale, num, form, test.form)
+				}
+			}
+		}
+	}
+}
+
+func TestCardinalSamples(t *testing.T) {
+	runSampleTests(t, DefaultRules(), []pluralSamples{ {{- template "samples" .Cardinal}}
+	})
+}
+{{if .Ordinal}}
+func TestOrdinalSamples(t *testing.T) {
+	runSampleTests(t, DefaultOrdinalRules(), []pluralSamples{ {{- template "samples" .Ordinal}}
+	})
 }
 {{end}}
-`))
+{{- define "samples"}}{{range $g := .}}{{range .PluralRules}}
+		{ {{- printf "%#v" $g.SplitLocales}}, {{.CountTitle}}, {{printf "%#v" .IntegerSamples}}, {{printf "%#v" .DecimalSamples}}},{{end}}{{end}}{{end}}`))
 
 func infof(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
@@ -7,10 +7,22 @@ import (
 	"strings"
 )
 
-// SupplementalData is the top level struct of plural.xml
+// SupplementalData is the top level struct of plurals.xml and ordinals.xml
 type SupplementalData struct {
-	XMLName      xml.Name      `xml:"supplementalData"`
-	PluralGroups []PluralGroup `xml:"plurals>pluralRules"`
+	XMLName xml.Name  `xml:"supplementalData"`
+	Version Version   `xml:"version"`
+	Plurals []Plurals `xml:"plurals"`
+}
+
+// Version is the version element of a CLDR supplemental data file.
+type Version struct {
+	CLDRVersion string `xml:"cldrVersion,attr"`
+}
+
+// Plurals is a set of plural groups of a type, either "cardinal" or "ordinal".
+type Plurals struct {
+	Type         string        `xml:"type,attr"`
+	PluralGroups []PluralGroup `xml:"pluralRules"`
 }
 
 // PluralGroup is a group of locales with the same plural rules.
@@ -43,13 +55,16 @@ func (pr *PluralRule) CountTitle() string {
 
 // Condition returns the condition where the PluralRule applies.
 func (pr *PluralRule) Condition() string {
-	i := strings.Index(pr.Rule, "@")
-	return pr.Rule[:i]
+	if i := strings.Index(pr.Rule, "@"); i >= 0 {
+		return pr.Rule[:i]
+	}
+	return pr.Rule
 }
 
 // Examples returns the integer and decimal exmaples for the PLuralRule.
 func (pr *PluralRule) Examples() (integer []string, decimal []string) {
 	ex := strings.Replace(pr.Rule, ", …", "", -1)
+	ex = strings.Replace(ex, "…", "", -1)
 	ddelim := "@decimal"
 	if i := strings.Index(ex, ddelim); i > 0 {
 		dex := strings.TrimSpace(ex[i+len(ddelim):])
@@ -64,6 +79,53 @@ func (pr *PluralRule) Examples() (integer []string, decimal []string) {
 	return integer, decimal
 }
 
+// IntegerSamples returns every integer sample of the PluralRule with ranges expanded.
+func (pr *PluralRule) IntegerSamples() []string {
+	return expandSamples(pr.IntegerExamples())
+}
+
+// DecimalSamples returns every decimal sample of the PluralRule with ranges expanded.
+func (pr *PluralRule) DecimalSamples() []string {
+	return expandSamples(pr.DecimalExamples())
+}
+
+// expandSamples expands sample ranges such as "0.0~1.5" into all the samples they contain.
+func expandSamples(examples []string) []string {
+	var expanded []string
+	for _, ex := range examples {
+		parts := strings.Split(ex, "~")
+		if len(parts) != 2 {
+			expanded = append(expanded, ex)
+			continue
+		}
+		for ex := parts[0]; ; ex = increment(ex) {
+			expanded = append(expanded, ex)
+			if ex == parts[1] || len(ex) > len(parts[1]) {
+				break
+			}
+		}
+	}
+	return expanded
+}
+
+// increment adds one to the last visible digit of the decimal string dec,
+// e.g. "0.9" becomes "1.0" and "99" becomes "100".
+func increment(dec string) string {
+	b := []byte(dec)
+	for i := len(b) - 1; i >= 0; i-- {
+		switch {
+		case b[i] == '.':
+			continue
+		case b[i] == '9':
+			b[i] = '0'
+		default:
+			b[i]++
+			return string(b)
+		}
+	}
+	return "1" + string(b)
+}
+
 // IntegerExamples returns the integer exmaples for the PLuralRule.
 func (pr *PluralRule) IntegerExamples() []string {
 	integer, _ := pr.Examples()
@@ -76,7 +138,7 @@ func (pr *PluralRule) DecimalExamples() []string {
 	return decimal
 }
 
-var relationRegexp = regexp.MustCompile(`([niftvw])(?:\s*%\s*([0-9]+))?\s*(!=|=)(.*)`)
+var relationRegexp = regexp.MustCompile(`([niftvwce])(?:\s*%\s*([0-9]+))?\s*(!=|=)(.*)`)
 
 // GoCondition converts the XML condition to valid Go code.
 func (pr *PluralRule) GoCondition() string {
@@ -89,6 +151,10 @@ func (pr *PluralRule) GoCondition() string {
 				continue
 			}
 			lvar, lmod, op, rhs := strings.Title(parts[1]), parts[2], parts[3], strings.TrimSpace(parts[4])
+			if lvar == "C" {
+				// c is a synonym for the compact decimal exponent e.
+				lvar = "E"
+			}
 			if op == "=" {
 				op = "=="
 			}
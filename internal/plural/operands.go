@@ -1,12 +1,19 @@
 package plural
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
 
 // Operands is a representation of http://unicode.org/reports/tr35/tr35-numbers.html#Operands
+//
+// Integer operands that do not fit into 18 decimal digits are stored as
+// 10^18 + (x mod 10^18). CLDR rules only compare operands against small
+// values and take them modulo powers of ten up to 10^6, so this keeps every
+// rule exact for arbitrarily large numbers.
 type Operands struct {
 	N float64 // absolute value of the source number (integer and decimals)
 	I int64   // integer digits of n
@@ -14,6 +21,22 @@ type Operands struct {
 	W int64   // number of visible fraction digits in n, without trailing zeros
 	F int64   // visible fractional digits in n, with trailing zeros
 	T int64   // visible fractional digits in n, without trailing zeros
+	E int64   // compact decimal exponent value (c is a synonym)
+}
+
+// Float is a floating point number that is formatted with
+// a fixed number of visible fraction digits before its operands are computed,
+// e.g. Float{Value: 1, Precision: 2} has the operands of "1.00".
+// A negative Precision uses the smallest number of digits
+// necessary to represent the value exactly.
+type Float struct {
+	Value     float64
+	Precision int
+}
+
+// String returns the decimal representation of f.
+func (f Float) String() string {
+	return strconv.FormatFloat(f.Value, 'f', f.Precision, 64)
 }
 
 // NEqualsAny returns true if o represents an integer equal to any of the arguments.
@@ -49,6 +72,11 @@ func (o *Operands) NModInRange(mod, from, to int64) bool {
 }
 
 // NewOperands returns the operands for number.
+//
+// Supported types are the integer types, Float, float32 and float64
+// (formatted with the shortest exact representation), *big.Int, *big.Float,
+// json.Number, strings and fmt.Stringer values returning a decimal string.
+// Strings may use CLDR compact notation such as "1.2c6".
 func NewOperands(number interface{}) (*Operands, error) {
 	switch number := number.(type) {
 	case int:
@@ -61,12 +89,43 @@ func NewOperands(number interface{}) (*Operands, error) {
 		return newOperandsInt64(int64(number)), nil
 	case int64:
 		return newOperandsInt64(number), nil
+	case uint:
+		return newOperandsString(strconv.FormatUint(uint64(number), 10))
+	case uint8:
+		return newOperandsInt64(int64(number)), nil
+	case uint16:
+		return newOperandsInt64(int64(number)), nil
+	case uint32:
+		return newOperandsInt64(int64(number)), nil
+	case uint64:
+		return newOperandsString(strconv.FormatUint(number, 10))
+	case float32:
+		return newOperandsString(strconv.FormatFloat(float64(number), 'f', -1, 32))
+	case float64:
+		return newOperandsString(strconv.FormatFloat(number, 'f', -1, 64))
+	case Float:
+		return newOperandsString(number.String())
+	case *big.Int:
+		if number == nil {
+			return nil, fmt.Errorf("nil *big.Int")
+		}
+		return newOperandsString(number.String())
+	case *big.Float:
+		if number == nil {
+			return nil, fmt.Errorf("nil *big.Float")
+		}
+		if number.IsInf() {
+			return nil, fmt.Errorf("infinite number %s", number)
+		}
+		return newOperandsString(number.Text('f', -1))
+	case json.Number:
+		return newOperandsString(string(number))
 	case string:
 		return newOperandsString(number)
-	case float32, float64:
-		return nil, fmt.Errorf("floats should be formatted into a string")
+	case fmt.Stringer:
+		return newOperandsString(number.String())
 	default:
-		return nil, fmt.Errorf("invalid type %T; expected integer or string", number)
+		return nil, fmt.Errorf("invalid type %T; expected number or string", number)
 	}
 }
 
@@ -74,47 +133,105 @@ func newOperandsInt64(i int64) *Operands {
 	if i < 0 {
 		i = -i
 	}
-	return &Operands{float64(i), i, 0, 0, 0, 0}
+	return &Operands{float64(i), i, 0, 0, 0, 0, 0}
 }
 
 func newOperandsString(s string) (*Operands, error) {
-	if s[0] == '-' {
+	if s == "" {
+		return nil, fmt.Errorf("empty number")
+	}
+	str := s
+	if s[0] == '-' || s[0] == '+' {
 		s = s[1:]
 	}
-	n, err := strconv.ParseFloat(s, 64)
-	if err != nil {
-		return nil, err
+
+	// Compact decimal notation, e.g. "1.2c6" (the CLDR spec also allows "e").
+	var exp int64
+	if i := strings.IndexAny(s, "ce"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 0); err != nil || exp < 0 {
+			return nil, fmt.Errorf("invalid exponent in %q", str)
+		}
+		if exp > maxExponent {
+			return nil, fmt.Errorf("exponent of %q is larger than %d", str, maxExponent)
+		}
+		s = s[:i]
 	}
-	ops := &Operands{N: n}
+
 	parts := strings.SplitN(s, ".", 2)
-	ops.I, err = strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return nil, err
+	integer, fraction := parts[0], ""
+	if len(parts) == 2 {
+		fraction = parts[1]
 	}
-	if len(parts) == 1 {
-		return ops, nil
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid number %q", str)
 	}
-	fraction := parts[1]
-	ops.V = int64(len(fraction))
-	for i := ops.V - 1; i >= 0; i-- {
-		if fraction[i] != '0' {
-			ops.W = i + 1
-			break
-		}
+	integer, fraction = shiftDecimal(integer, fraction, int(exp))
+
+	// The digits are already validated, so the only possible error is
+	// a range error for numbers too large for a float64, which N approximates.
+	n, _ := strconv.ParseFloat(integer+"."+fraction+"0", 64)
+	ops := &Operands{N: n, E: exp}
+	var err error
+	if ops.I, err = parseDigits(integer); err != nil {
+		return nil, err
 	}
+	ops.V = int64(len(fraction))
+	ops.W = int64(len(strings.TrimRight(fraction, "0")))
 	if ops.V > 0 {
-		f, err := strconv.ParseInt(fraction, 10, 0)
-		if err != nil {
+		if ops.F, err = parseDigits(fraction); err != nil {
 			return nil, err
 		}
-		ops.F = f
 	}
 	if ops.W > 0 {
-		t, err := strconv.ParseInt(fraction[:ops.W], 10, 0)
-		if err != nil {
+		if ops.T, err = parseDigits(fraction[:ops.W]); err != nil {
 			return nil, err
 		}
-		ops.T = t
 	}
 	return ops, nil
 }
+
+// maxExponent is the largest compact decimal exponent, far above the exponents of CLDR samples,
+// so that counts from untrusted input cannot expand to huge numbers of digits.
+const maxExponent = 100
+
+// shiftDecimal moves the decimal point exp digits to the right.
+func shiftDecimal(integer, fraction string, exp int) (string, string) {
+	if exp == 0 {
+		return integer, fraction
+	}
+	if exp >= len(fraction) {
+		return integer + fraction + strings.Repeat("0", exp-len(fraction)), ""
+	}
+	return integer + fraction[:exp], fraction[exp:]
+}
+
+// maxDigits is the number of decimal digits that parseDigits keeps exactly.
+const maxDigits = 18
+
+// parseDigits parses a string of decimal digits.
+// Values with more than maxDigits significant digits are reduced
+// to 10^maxDigits plus their lowest maxDigits digits.
+func parseDigits(s string) (int64, error) {
+	s = strings.TrimLeft(s, "0")
+	if len(s) <= maxDigits {
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.ParseInt(s, 10, 64)
+	}
+	low, err := strconv.ParseInt(s[len(s)-maxDigits:], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return 1e18 + low, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
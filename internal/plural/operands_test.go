@@ -0,0 +1,45 @@
+package plural
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewOperands(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		ops   *Operands
+	}{
+		{int64(0), &Operands{0, 0, 0, 0, 0, 0, 0}},
+		{1, &Operands{1, 1, 0, 0, 0, 0, 0}},
+		{-1, &Operands{1, 1, 0, 0, 0, 0, 0}},
+		{"1.0", &Operands{1, 1, 1, 0, 0, 0, 0}},
+		{"1.50", &Operands{1.5, 1, 2, 1, 50, 5, 0}},
+		{"1.2c0", &Operands{1.2, 1, 1, 1, 2, 2, 0}},
+		{"1.2c3", &Operands{1200, 1200, 0, 0, 0, 0, 3}},
+		{"1.2345c2", &Operands{123.45, 123, 2, 2, 45, 45, 2}},
+		{"1e6", &Operands{1000000, 1000000, 0, 0, 0, 0, 6}},
+		{Float{Value: 1, Precision: 2}, &Operands{1, 1, 2, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		ops, err := NewOperands(test.input)
+		if err != nil {
+			t.Errorf("NewOperands(%#v) returned error %s", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(ops, test.ops) {
+			t.Errorf("NewOperands(%#v) = %#v; expected %#v", test.input, ops, test.ops)
+		}
+	}
+}
+
+func TestNewOperandsExponent(t *testing.T) {
+	if _, err := NewOperands("1c100"); err != nil {
+		t.Errorf("NewOperands(\"1c100\") returned error %s", err)
+	}
+	for _, input := range []string{"1c-1", "1e-3", "1c101", "1c99999999999", "1c", "1c+"} {
+		if ops, err := NewOperands(input); err == nil {
+			t.Errorf("NewOperands(%q) = %#v; expected an error", input, ops)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package plural
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseRule returns the Rule described by conditions written in the CLDR plural rule syntax,
+// e.g. "n = 1 or t != 0 and i = 0,1" for One.
+// Samples after "@integer" or "@decimal" are ignored.
+// http://unicode.org/reports/tr35/tr35-numbers.html#Plural_rules_syntax
+//
+// Other is always part of the returned Rule and must not have a condition.
+func ParseRule(conditions map[Form]string) (*Rule, error) {
+	type formCondition struct {
+		form      Form
+		condition condition
+	}
+	for form := range conditions {
+		if !isValidForm(form) {
+			return nil, fmt.Errorf("unknown plural form %q", form)
+		}
+	}
+	var fcs []formCondition
+	forms := []Form{Other}
+	for _, form := range []Form{Zero, One, Two, Few, Many, Other} {
+		src, ok := conditions[form]
+		if !ok {
+			continue
+		}
+		c, err := parseCondition(src)
+		if err != nil {
+			return nil, fmt.Errorf("plural form %q: %s", form, err)
+		}
+		if form == Other {
+			if c != nil {
+				return nil, fmt.Errorf("plural form %q must not have a condition", Other)
+			}
+			continue
+		}
+		if c == nil {
+			return nil, fmt.Errorf("plural form %q has no condition", form)
+		}
+		fcs = append(fcs, formCondition{form: form, condition: c})
+		forms = append(forms, form)
+	}
+	return &Rule{
+		PluralForms: newPluralFormSet(forms...),
+		PluralFormFunc: func(ops *Operands) Form {
+			for _, fc := range fcs {
+				if fc.condition.matches(ops) {
+					return fc.form
+				}
+			}
+			return Other
+		},
+	}, nil
+}
+
+func isValidForm(form Form) bool {
+	switch form {
+	case Zero, One, Two, Few, Many, Other:
+		return true
+	}
+	return false
+}
+
+// condition is a disjunction of conjunctions of relations.
+type condition [][]relation
+
+func (c condition) matches(ops *Operands) bool {
+	for _, and := range c {
+		matches := true
+		for _, r := range and {
+			if !r.matches(ops) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// relation is a single comparison such as "i % 10 != 2..4,7".
+type relation struct {
+	operand byte
+	mod     int64
+	negate  bool
+	ranges  [][2]int64
+}
+
+func (r *relation) matches(ops *Operands) bool {
+	var value int64
+	switch r.operand {
+	case 'n':
+		// n only equals an integer if it has no visible fraction digits other than zeros.
+		if ops.T != 0 {
+			return r.negate
+		}
+		value = ops.I
+	case 'i':
+		value = ops.I
+	case 'v':
+		value = ops.V
+	case 'w':
+		value = ops.W
+	case 'f':
+		value = ops.F
+	case 't':
+		value = ops.T
+	case 'c', 'e':
+		value = ops.E
+	}
+	if r.mod != 0 {
+		value %= r.mod
+	}
+	for _, rg := range r.ranges {
+		if intInRange(value, rg[0], rg[1]) {
+			return !r.negate
+		}
+	}
+	return r.negate
+}
+
+// parseCondition parses src and returns nil if it has no condition.
+func parseCondition(src string) (condition, error) {
+	if i := strings.Index(src, "@"); i >= 0 {
+		src = src[:i]
+	}
+	p := &ruleParser{tokens: tokenizeRule(src)}
+	if len(p.tokens) == 0 {
+		return nil, nil
+	}
+	var c condition
+	for {
+		var and []relation
+		for {
+			r, err := p.relation()
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, r)
+			if !p.accept("and") {
+				break
+			}
+		}
+		c = append(c, and)
+		if !p.accept("or") {
+			break
+		}
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	return c, nil
+}
+
+type ruleParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *ruleParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *ruleParser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *ruleParser) accept(tok string) bool {
+	if p.peek() == tok {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *ruleParser) relation() (relation, error) {
+	var r relation
+	operand := p.next()
+	if len(operand) != 1 || !strings.Contains("niftvwce", operand) {
+		return r, fmt.Errorf("expected operand but got %q", operand)
+	}
+	r.operand = operand[0]
+	if p.accept("%") {
+		mod, err := p.value()
+		if err != nil {
+			return r, err
+		}
+		if mod == 0 {
+			return r, fmt.Errorf("modulus must not be zero")
+		}
+		r.mod = mod
+	}
+	switch op := p.next(); op {
+	case "=":
+	case "!=":
+		r.negate = true
+	default:
+		return r, fmt.Errorf("expected = or != but got %q", op)
+	}
+	for {
+		from, err := p.value()
+		if err != nil {
+			return r, err
+		}
+		to := from
+		if p.accept("..") {
+			if to, err = p.value(); err != nil {
+				return r, err
+			}
+		}
+		r.ranges = append(r.ranges, [2]int64{from, to})
+		if !p.accept(",") {
+			break
+		}
+	}
+	return r, nil
+}
+
+func (p *ruleParser) value() (int64, error) {
+	tok := p.next()
+	v, err := strconv.ParseInt(tok, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected value but got %q", tok)
+	}
+	return v, nil
+}
+
+// tokenizeRule splits src into words, numbers and the symbols "=", "!=", "%", "," and "..".
+func tokenizeRule(src string) []string {
+	var tokens []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "!="), strings.HasPrefix(src[i:], ".."):
+			tokens = append(tokens, src[i:i+2])
+			i += 2
+		case isAlnum(c):
+			j := i
+			for j < len(src) && isAlnum(src[j]) {
+				j++
+			}
+			tokens = append(tokens, src[i:j])
+			i = j
+		default:
+			tokens = append(tokens, src[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,100 @@
+package plural
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions map[Form]string
+		forms      []Form
+		samples    map[string]Form
+	}{
+		{
+			name:       "request example",
+			conditions: map[Form]string{One: "n = 1 or t != 0 and i = 0,1", Other: ""},
+			forms:      []Form{One, Other},
+			samples: map[string]Form{
+				"0": Other, "1": One, "1.0": One, "2": Other,
+				"0.1": One, "1.5": One, "2.5": Other,
+			},
+		},
+		{
+			name: "ranges and modulo",
+			conditions: map[Form]string{
+				One:  "v = 0 and i % 10 = 1 and i % 100 != 11",
+				Few:  "v = 0 and i % 10 = 2..4 and i % 100 != 12..14",
+				Many: "v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14",
+			},
+			forms: []Form{One, Few, Many, Other},
+			samples: map[string]Form{
+				"1": One, "21": One, "101": One, "11": Many,
+				"2": Few, "24": Few, "12": Many, "14": Many,
+				"0": Many, "5": Many, "1.5": Other,
+			},
+		},
+		{
+			name:       "value lists",
+			conditions: map[Form]string{Few: "n = 0,2..3,7", Other: ""},
+			forms:      []Form{Few, Other},
+			samples:    map[string]Form{"0": Few, "1": Other, "2": Few, "3": Few, "4": Other, "7": Few, "2.5": Other},
+		},
+		{
+			name:       "and binds tighter than or",
+			conditions: map[Form]string{One: "i = 5 or i = 1 and v = 1"},
+			forms:      []Form{One, Other},
+			samples:    map[string]Form{"5": One, "1": Other, "1.0": One, "5.0": One},
+		},
+		{
+			name:       "exponent and samples",
+			conditions: map[Form]string{Many: "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5 @integer 1000000, 1c6", Other: "@integer 0~5"},
+			forms:      []Form{Many, Other},
+			samples:    map[string]Form{"1000000": Many, "1c6": Many, "1c3": Other, "5": Other},
+		},
+	}
+	for _, test := range tests {
+		rule, err := ParseRule(test.conditions)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(rule.PluralForms, newPluralFormSet(test.forms...)) {
+			t.Errorf("%s: plural forms are %v; expected %v", test.name, rule.PluralForms, test.forms)
+		}
+		for sample, form := range test.samples {
+			ops, err := NewOperands(sample)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f := rule.PluralFormFunc(ops); f != form {
+				t.Errorf("%s: %s is %s; expected %s", test.name, sample, f, form)
+			}
+		}
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	tests := []map[Form]string{
+		{One: ""},
+		{One: "n = "},
+		{One: "n == 1"},
+		{One: "n = 1 and"},
+		{One: "n = 1 or"},
+		{One: "n = 1 1"},
+		{One: "n = a"},
+		{One: "n = 1..", Other: ""},
+		{One: "x = 1"},
+		{One: "n % 0 = 1"},
+		{One: "n % = 1"},
+		{One: "(n = 1)"},
+		{Other: "n = 1"},
+		{"several": "n = 1"},
+	}
+	for _, conditions := range tests {
+		if rule, err := ParseRule(conditions); err == nil {
+			t.Errorf("ParseRule(%v) = %v; expected an error", conditions, rule)
+		}
+	}
+}
@@ -2,17 +2,20 @@
 
 package plural
 
+// CLDRVersion is the version of the CLDR data the rules were generated from.
+const CLDRVersion = "47"
+
 // DefaultRules returns a map of Rules generated from CLDR language data.
 func DefaultRules() Rules {
 	rules := Rules{}
 
-	addPluralRules(rules, []string{"bm", "bo", "dz", "id", "ig", "ii", "in", "ja", "jbo", "jv", "jw", "kde", "kea", "km", "ko", "lkt", "lo", "ms", "my", "nqo", "osa", "root", "sah", "ses", "sg", "su", "th", "to", "vi", "wo", "yo", "yue", "zh"}, &Rule{
+	addPluralRules(rules, []string{"bm", "bo", "dz", "hnj", "id", "ig", "ii", "in", "ja", "jbo", "jv", "jw", "kde", "kea", "km", "ko", "lkt", "lo", "ms", "my", "nqo", "osa", "root", "sah", "ses", "sg", "su", "th", "to", "tpi", "vi", "wo", "yo", "yue", "zh"}, &Rule{
 		PluralForms: newPluralFormSet(Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"am", "as", "bn", "fa", "gu", "hi", "kn", "pcm", "zu"}, &Rule{
+	addPluralRules(rules, []string{"am", "as", "bn", "doi", "fa", "gu", "hi", "kn", "kok", "kok_Latn", "pcm", "zu"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// i = 0 or n = 1
@@ -23,7 +26,7 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"ff", "fr", "hy", "kab"}, &Rule{
+	addPluralRules(rules, []string{"ff", "hy", "kab"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// i = 0,1
@@ -33,17 +36,7 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"pt"}, &Rule{
-		PluralForms: newPluralFormSet(One, Other),
-		PluralFormFunc: func(ops *Operands) Form {
-			// i = 0..1
-			if intInRange(ops.I, 0, 1) {
-				return One
-			}
-			return Other
-		},
-	})
-	addPluralRules(rules, []string{"ast", "ca", "de", "en", "et", "fi", "fy", "gl", "ia", "io", "it", "ji", "nl", "pt_PT", "sc", "scn", "sv", "sw", "ur", "yi"}, &Rule{
+	addPluralRules(rules, []string{"ast", "de", "en", "et", "fi", "fy", "gl", "ia", "ie", "io", "ji", "lij", "nl", "sc", "sv", "sw", "ur", "yi"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// i = 1 and v = 0
@@ -64,7 +57,7 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"ak", "bho", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, &Rule{
+	addPluralRules(rules, []string{"ak", "bho", "csw", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// n = 0..1
@@ -85,7 +78,7 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"af", "an", "asa", "az", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "es", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "mr", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sd", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, &Rule{
+	addPluralRules(rules, []string{"af", "an", "asa", "az", "bal", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "mr", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sd", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// n = 1
@@ -109,9 +102,9 @@ func DefaultRules() Rules {
 	addPluralRules(rules, []string{"is"}, &Rule{
 		PluralForms: newPluralFormSet(One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
-			// t = 0 and i % 10 = 1 and i % 100 != 11 or t != 0
+			// t = 0 and i % 10 = 1 and i % 100 != 11 or t % 10 = 1 and t % 100 != 11
 			if intEqualsAny(ops.T, 0) && intEqualsAny(ops.I%10, 1) && !intEqualsAny(ops.I%100, 11) ||
-				!intEqualsAny(ops.T, 0) {
+				intEqualsAny(ops.T%10, 1) && !intEqualsAny(ops.T%100, 11) {
 				return One
 			}
 			return Other
@@ -172,7 +165,7 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"ksh"}, &Rule{
+	addPluralRules(rules, []string{"blo", "cv", "ksh"}, &Rule{
 		PluralForms: newPluralFormSet(Zero, One, Other),
 		PluralFormFunc: func(ops *Operands) Form {
 			// n = 0
@@ -186,6 +179,21 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
+	addPluralRules(rules, []string{"he", "iw"}, &Rule{
+		PluralForms: newPluralFormSet(One, Two, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// i = 1 and v = 0 or i = 0 and v != 0
+			if intEqualsAny(ops.I, 1) && intEqualsAny(ops.V, 0) ||
+				intEqualsAny(ops.I, 0) && !intEqualsAny(ops.V, 0) {
+				return One
+			}
+			// i = 2 and v = 0
+			if intEqualsAny(ops.I, 2) && intEqualsAny(ops.V, 0) {
+				return Two
+			}
+			return Other
+		},
+	})
 	addPluralRules(rules, []string{"iu", "naq", "sat", "se", "sma", "smi", "smj", "smn", "sms"}, &Rule{
 		PluralForms: newPluralFormSet(One, Two, Other),
 		PluralFormFunc: func(ops *Operands) Form {
@@ -222,10 +230,10 @@ func DefaultRules() Rules {
 			if intEqualsAny(ops.I, 1) && intEqualsAny(ops.V, 0) {
 				return One
 			}
-			// v != 0 or n = 0 or n % 100 = 2..19
+			// v != 0 or n = 0 or n != 1 and n % 100 = 1..19
 			if !intEqualsAny(ops.V, 0) ||
 				ops.NEqualsAny(0) ||
-				ops.NModInRange(100, 2, 19) {
+				!ops.NEqualsAny(1) && ops.NModInRange(100, 1, 19) {
 				return Few
 			}
 			return Other
@@ -247,6 +255,66 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
+	addPluralRules(rules, []string{"fr"}, &Rule{
+		PluralForms: newPluralFormSet(One, Many, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// i = 0,1
+			if intEqualsAny(ops.I, 0, 1) {
+				return One
+			}
+			// e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5
+			if intEqualsAny(ops.E, 0) && !intEqualsAny(ops.I, 0) && intEqualsAny(ops.I%1000000, 0) && intEqualsAny(ops.V, 0) ||
+				!intInRange(ops.E, 0, 5) {
+				return Many
+			}
+			return Other
+		},
+	})
+	addPluralRules(rules, []string{"pt"}, &Rule{
+		PluralForms: newPluralFormSet(One, Many, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// i = 0..1
+			if intInRange(ops.I, 0, 1) {
+				return One
+			}
+			// e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5
+			if intEqualsAny(ops.E, 0) && !intEqualsAny(ops.I, 0) && intEqualsAny(ops.I%1000000, 0) && intEqualsAny(ops.V, 0) ||
+				!intInRange(ops.E, 0, 5) {
+				return Many
+			}
+			return Other
+		},
+	})
+	addPluralRules(rules, []string{"ca", "it", "lld", "pt_PT", "scn", "vec"}, &Rule{
+		PluralForms: newPluralFormSet(One, Many, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// i = 1 and v = 0
+			if intEqualsAny(ops.I, 1) && intEqualsAny(ops.V, 0) {
+				return One
+			}
+			// e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5
+			if intEqualsAny(ops.E, 0) && !intEqualsAny(ops.I, 0) && intEqualsAny(ops.I%1000000, 0) && intEqualsAny(ops.V, 0) ||
+				!intInRange(ops.E, 0, 5) {
+				return Many
+			}
+			return Other
+		},
+	})
+	addPluralRules(rules, []string{"es"}, &Rule{
+		PluralForms: newPluralFormSet(One, Many, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// n = 1
+			if ops.NEqualsAny(1) {
+				return One
+			}
+			// e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5
+			if intEqualsAny(ops.E, 0) && !intEqualsAny(ops.I, 0) && intEqualsAny(ops.I%1000000, 0) && intEqualsAny(ops.V, 0) ||
+				!intInRange(ops.E, 0, 5) {
+				return Many
+			}
+			return Other
+		},
+	})
 	addPluralRules(rules, []string{"gd"}, &Rule{
 		PluralForms: newPluralFormSet(One, Two, Few, Other),
 		PluralFormFunc: func(ops *Operands) Form {
@@ -305,24 +373,6 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"he", "iw"}, &Rule{
-		PluralForms: newPluralFormSet(One, Two, Many, Other),
-		PluralFormFunc: func(ops *Operands) Form {
-			// i = 1 and v = 0
-			if intEqualsAny(ops.I, 1) && intEqualsAny(ops.V, 0) {
-				return One
-			}
-			// i = 2 and v = 0
-			if intEqualsAny(ops.I, 2) && intEqualsAny(ops.V, 0) {
-				return Two
-			}
-			// v = 0 and n != 0..10 and n % 10 = 0
-			if intEqualsAny(ops.V, 0) && !ops.NInRange(0, 10) && ops.NModEqualsAny(10, 0) {
-				return Many
-			}
-			return Other
-		},
-	})
 	addPluralRules(rules, []string{"cs", "sk"}, &Rule{
 		PluralForms: newPluralFormSet(One, Few, Many, Other),
 		PluralFormFunc: func(ops *Operands) Form {
@@ -399,40 +449,43 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"mt"}, &Rule{
+	addPluralRules(rules, []string{"ru", "uk"}, &Rule{
 		PluralForms: newPluralFormSet(One, Few, Many, Other),
 		PluralFormFunc: func(ops *Operands) Form {
-			// n = 1
-			if ops.NEqualsAny(1) {
+			// v = 0 and i % 10 = 1 and i % 100 != 11
+			if intEqualsAny(ops.V, 0) && intEqualsAny(ops.I%10, 1) && !intEqualsAny(ops.I%100, 11) {
 				return One
 			}
-			// n = 0 or n % 100 = 2..10
-			if ops.NEqualsAny(0) ||
-				ops.NModInRange(100, 2, 10) {
+			// v = 0 and i % 10 = 2..4 and i % 100 != 12..14
+			if intEqualsAny(ops.V, 0) && intInRange(ops.I%10, 2, 4) && !intInRange(ops.I%100, 12, 14) {
 				return Few
 			}
-			// n % 100 = 11..19
-			if ops.NModInRange(100, 11, 19) {
+			// v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14
+			if intEqualsAny(ops.V, 0) && intEqualsAny(ops.I%10, 0) ||
+				intEqualsAny(ops.V, 0) && intInRange(ops.I%10, 5, 9) ||
+				intEqualsAny(ops.V, 0) && intInRange(ops.I%100, 11, 14) {
 				return Many
 			}
 			return Other
 		},
 	})
-	addPluralRules(rules, []string{"ru", "uk"}, &Rule{
-		PluralForms: newPluralFormSet(One, Few, Many, Other),
+	addPluralRules(rules, []string{"sgs"}, &Rule{
+		PluralForms: newPluralFormSet(One, Two, Few, Many, Other),
 		PluralFormFunc: func(ops *Operands) Form {
-			// v = 0 and i % 10 = 1 and i % 100 != 11
-			if intEqualsAny(ops.V, 0) && intEqualsAny(ops.I%10, 1) && !intEqualsAny(ops.I%100, 11) {
+			// n % 10 = 1 and n % 100 != 11
+			if ops.NModEqualsAny(10, 1) && !ops.NModEqualsAny(100, 11) {
 				return One
 			}
-			// v = 0 and i % 10 = 2..4 and i % 100 != 12..14
-			if intEqualsAny(ops.V, 0) && intInRange(ops.I%10, 2, 4) && !intInRange(ops.I%100, 12, 14) {
+			// n = 2
+			if ops.NEqualsAny(2) {
+				return Two
+			}
+			// n != 2 and n % 10 = 2..9 and n % 100 != 11..19
+			if !ops.NEqualsAny(2) && ops.NModInRange(10, 2, 9) && !ops.NModInRange(100, 11, 19) {
 				return Few
 			}
-			// v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14
-			if intEqualsAny(ops.V, 0) && intEqualsAny(ops.I%10, 0) ||
-				intEqualsAny(ops.V, 0) && intInRange(ops.I%10, 5, 9) ||
-				intEqualsAny(ops.V, 0) && intInRange(ops.I%100, 11, 14) {
+			// f != 0
+			if !intEqualsAny(ops.F, 0) {
 				return Many
 			}
 			return Other
@@ -460,6 +513,29 @@ func DefaultRules() Rules {
 			return Other
 		},
 	})
+	addPluralRules(rules, []string{"mt"}, &Rule{
+		PluralForms: newPluralFormSet(One, Two, Few, Many, Other),
+		PluralFormFunc: func(ops *Operands) Form {
+			// n = 1
+			if ops.NEqualsAny(1) {
+				return One
+			}
+			// n = 2
+			if ops.NEqualsAny(2) {
+				return Two
+			}
+			// n = 0 or n % 100 = 3..10
+			if ops.NEqualsAny(0) ||
+				ops.NModInRange(100, 3, 10) {
+				return Few
+			}
+			// n % 100 = 11..19
+			if ops.NModInRange(100, 11, 19) {
+				return Many
+			}
+			return Other
+		},
+	})
 	addPluralRules(rules, []string{"ga"}, &Rule{
 		PluralForms: newPluralFormSet(One, Two, Few, Many, Other),
 		PluralFormFunc: func(ops *Operands) Form {
@@ -0,0 +1,192 @@
+// This file is generated by i18n/plural/codegen/generate.sh; DO NOT EDIT
+
+package plural
+
+import (
+	"strconv"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+type pluralSamples struct {
+	locales []string
+	form    Form
+	integer []string
+	decimal []string
+}
+
+// runSampleTests checks that every @integer and @decimal sample of CLDR 47 selects its plural form.
+func runSampleTests(t *testing.T, rules Rules, tests []pluralSamples) {
+	for _, test := range tests {
+		for _, locale := range test.locales {
+			if locale == "root" {
+				continue
+			}
+			rule := rules.Rule(language.MustParse(locale))
+			if rule == nil {
+				t.Errorf("could not find plural rule for locale %s", locale)
+				continue
+			}
+			var nums []interface{}
+			for _, sample := range test.integer {
+				nums = append(nums, sample)
+				if i, err := strconv.ParseInt(sample, 10, 64); err == nil {
+					nums = append(nums, i)
+				}
+			}
+			for _, sample := range test.decimal {
+				nums = append(nums, sample)
+			}
+			for _, num := range nums {
+				ops, err := NewOperands(num)
+				if err != nil {
+					t.Errorf("%s: NewOperands(%#v) errored with %s", locale, num, err)
+					continue
+				}
+				if form := rule.PluralFormFunc(ops); form != test.form {
+					t.Errorf("%s: PluralFormFunc(%#v) returned %q; expected %q", locale, num, form, test.form)
+				}
+			}
+		}
+	}
+}
+
+func TestCardinalSamples(t *testing.T) {
+	runSampleTests(t, DefaultRules(), []pluralSamples{
+		{[]string{"bm", "bo", "dz", "hnj", "id", "ig", "ii", "in", "ja", "jbo", "jv", "jw", "kde", "kea", "km", "ko", "lkt", "lo", "ms", "my", "nqo", "osa", "root", "sah", "ses", "sg", "su", "th", "to", "tpi", "vi", "wo", "yo", "yue", "zh"}, Other, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"am", "as", "bn", "doi", "fa", "gu", "hi", "kn", "kok", "kok_Latn", "pcm", "zu"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "0.00", "0.01", "0.02", "0.03", "0.04"}},
+		{[]string{"am", "as", "bn", "doi", "fa", "gu", "hi", "kn", "kok", "kok_Latn", "pcm", "zu"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ff", "hy", "kab"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5"}},
+		{[]string{"ff", "hy", "kab"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9", "3.0", "3.1", "3.2", "3.3", "3.4", "3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ast", "de", "en", "et", "fi", "fy", "gl", "ia", "ie", "io", "ji", "lij", "nl", "sc", "sv", "sw", "ur", "yi"}, One, []string{"1"}, []string(nil)},
+		{[]string{"ast", "de", "en", "et", "fi", "fy", "gl", "ia", "ie", "io", "ji", "lij", "nl", "sc", "sv", "sw", "ur", "yi"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"si"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "1.0", "0.00", "0.01", "1.00", "0.000", "0.001", "1.000", "0.0000", "0.0001", "1.0000"}},
+		{[]string{"si"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ak", "bho", "csw", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, One, []string{"0", "1"}, []string{"0.0", "1.0", "0.00", "1.00", "0.000", "1.000", "0.0000", "1.0000"}},
+		{[]string{"ak", "bho", "csw", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"tzm"}, One, []string{"0", "1", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24"}, []string{"0.0", "1.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "19.0", "20.0", "21.0", "22.0", "23.0", "24.0"}},
+		{[]string{"tzm"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "100", "101", "102", "103", "104", "105", "106", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"af", "an", "asa", "az", "bal", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "mr", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sd", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"af", "an", "asa", "az", "bal", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "mr", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sd", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"da"}, One, []string{"1"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6"}},
+		{[]string{"da"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9", "3.0", "3.1", "3.2", "3.3", "3.4", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"is"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"0.1", "1.0", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"}},
+		{[]string{"is"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"mk"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"}},
+		{[]string{"mk"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ceb", "fil", "tl"}, One, []string{"0", "1", "2", "3", "5", "7", "8", "10", "11", "12", "13", "15", "17", "18", "20", "21", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.5", "0.7", "0.8", "1.0", "1.1", "1.2", "1.3", "1.5", "1.7", "1.8", "2.0", "2.1", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ceb", "fil", "tl"}, Other, []string{"4", "6", "9", "14", "16", "19", "24", "26", "104", "1004"}, []string{"0.4", "0.6", "0.9", "1.4", "1.6", "1.9", "2.4", "2.6", "10.4", "100.4", "1000.4"}},
+		{[]string{"lv", "prg"}, Zero, []string{"0", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "30", "40", "50", "60", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "10.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"lv", "prg"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"0.1", "1.0", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"}},
+		{[]string{"lv", "prg"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "22", "23", "24", "25", "26", "27", "28", "29", "102", "1002"}, []string{"0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "10.2", "100.2", "1000.2"}},
+		{[]string{"lag"}, Zero, []string{"0"}, []string{"0.0", "0.00", "0.000", "0.0000"}},
+		{[]string{"lag"}, One, []string{"1"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6"}},
+		{[]string{"lag"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9", "3.0", "3.1", "3.2", "3.3", "3.4", "3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"blo", "cv", "ksh"}, Zero, []string{"0"}, []string{"0.0", "0.00", "0.000", "0.0000"}},
+		{[]string{"blo", "cv", "ksh"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"blo", "cv", "ksh"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"he", "iw"}, One, []string{"1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "0.00", "0.01", "0.02", "0.03", "0.04", "0.05"}},
+		{[]string{"he", "iw"}, Two, []string{"2"}, []string(nil)},
+		{[]string{"he", "iw"}, Other, []string{"0", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"iu", "naq", "sat", "se", "sma", "smi", "smj", "smn", "sms"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"iu", "naq", "sat", "se", "sma", "smi", "smj", "smn", "sms"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"iu", "naq", "sat", "se", "sma", "smi", "smj", "smn", "sms"}, Other, []string{"0", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"shi"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "0.00", "0.01", "0.02", "0.03", "0.04"}},
+		{[]string{"shi"}, Few, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10"}, []string{"2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "2.00", "3.00", "4.00", "5.00", "6.00", "7.00", "8.00"}},
+		{[]string{"shi"}, Other, []string{"11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "26", "100", "1000", "10000", "100000", "1000000"}, []string{"1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"mo", "ro"}, One, []string{"1"}, []string(nil)},
+		{[]string{"mo", "ro"}, Few, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "101", "1001"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"mo", "ro"}, Other, []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "31", "32", "33", "34", "35", "100", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"bs", "hr", "sh", "sr"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"}},
+		{[]string{"bs", "hr", "sh", "sr"}, Few, []string{"2", "3", "4", "22", "23", "24", "32", "33", "34", "42", "43", "44", "52", "53", "54", "62", "102", "1002"}, []string{"0.2", "0.3", "0.4", "1.2", "1.3", "1.4", "2.2", "2.3", "2.4", "3.2", "3.3", "3.4", "4.2", "4.3", "4.4", "5.2", "10.2", "100.2", "1000.2"}},
+		{[]string{"bs", "hr", "sh", "sr"}, Other, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.5", "1.6", "1.7", "1.8", "1.9", "2.0", "2.5", "2.6", "2.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"fr"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5"}},
+		{[]string{"fr"}, Many, []string{"1000000", "1c6", "2c6", "3c6", "4c6", "5c6", "6c6"}, []string{"1.0000001c6", "1.1c6", "2.0000001c6", "2.1c6", "3.0000001c6", "3.1c6"}},
+		{[]string{"fr"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1c3", "2c3", "3c3", "4c3", "5c3", "6c3"}, []string{"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9", "3.0", "3.1", "3.2", "3.3", "3.4", "3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0", "1.0001c3", "1.1c3", "2.0001c3", "2.1c3", "3.0001c3", "3.1c3"}},
+		{[]string{"pt"}, One, []string{"0", "1"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5"}},
+		{[]string{"pt"}, Many, []string{"1000000", "1c6", "2c6", "3c6", "4c6", "5c6", "6c6"}, []string{"1.0000001c6", "1.1c6", "2.0000001c6", "2.1c6", "3.0000001c6", "3.1c6"}},
+		{[]string{"pt"}, Other, []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "100", "1000", "10000", "100000", "1c3", "2c3", "3c3", "4c3", "5c3", "6c3"}, []string{"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7", "2.8", "2.9", "3.0", "3.1", "3.2", "3.3", "3.4", "3.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0", "1.0001c3", "1.1c3", "2.0001c3", "2.1c3", "3.0001c3", "3.1c3"}},
+		{[]string{"ca", "it", "lld", "pt_PT", "scn", "vec"}, One, []string{"1"}, []string(nil)},
+		{[]string{"ca", "it", "lld", "pt_PT", "scn", "vec"}, Many, []string{"1000000", "1c6", "2c6", "3c6", "4c6", "5c6", "6c6"}, []string{"1.0000001c6", "1.1c6", "2.0000001c6", "2.1c6", "3.0000001c6", "3.1c6"}},
+		{[]string{"ca", "it", "lld", "pt_PT", "scn", "vec"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1c3", "2c3", "3c3", "4c3", "5c3", "6c3"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0", "1.0001c3", "1.1c3", "2.0001c3", "2.1c3", "3.0001c3", "3.1c3"}},
+		{[]string{"es"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"es"}, Many, []string{"1000000", "1c6", "2c6", "3c6", "4c6", "5c6", "6c6"}, []string{"1.0000001c6", "1.1c6", "2.0000001c6", "2.1c6", "3.0000001c6", "3.1c6"}},
+		{[]string{"es"}, Other, []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "100", "1000", "10000", "100000", "1c3", "2c3", "3c3", "4c3", "5c3", "6c3"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0", "1.0001c3", "1.1c3", "2.0001c3", "2.1c3", "3.0001c3", "3.1c3"}},
+		{[]string{"gd"}, One, []string{"1", "11"}, []string{"1.0", "11.0", "1.00", "11.00", "1.000", "11.000", "1.0000"}},
+		{[]string{"gd"}, Two, []string{"2", "12"}, []string{"2.0", "12.0", "2.00", "12.00", "2.000", "12.000", "2.0000"}},
+		{[]string{"gd"}, Few, []string{"3", "4", "5", "6", "7", "8", "9", "10", "13", "14", "15", "16", "17", "18", "19"}, []string{"3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "19.0", "3.00"}},
+		{[]string{"gd"}, Other, []string{"0", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "31", "32", "33", "34", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"sl"}, One, []string{"1", "101", "201", "301", "401", "501", "601", "701", "1001"}, []string(nil)},
+		{[]string{"sl"}, Two, []string{"2", "102", "202", "302", "402", "502", "602", "702", "1002"}, []string(nil)},
+		{[]string{"sl"}, Few, []string{"3", "4", "103", "104", "203", "204", "303", "304", "403", "404", "503", "504", "603", "604", "703", "704", "1003"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"sl"}, Other, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"dsb", "hsb"}, One, []string{"1", "101", "201", "301", "401", "501", "601", "701", "1001"}, []string{"0.1", "1.1", "2.1", "3.1", "4.1", "5.1", "6.1", "7.1", "10.1", "100.1", "1000.1"}},
+		{[]string{"dsb", "hsb"}, Two, []string{"2", "102", "202", "302", "402", "502", "602", "702", "1002"}, []string{"0.2", "1.2", "2.2", "3.2", "4.2", "5.2", "6.2", "7.2", "10.2", "100.2", "1000.2"}},
+		{[]string{"dsb", "hsb"}, Few, []string{"3", "4", "103", "104", "203", "204", "303", "304", "403", "404", "503", "504", "603", "604", "703", "704", "1003"}, []string{"0.3", "0.4", "1.3", "1.4", "2.3", "2.4", "3.3", "3.4", "4.3", "4.4", "5.3", "5.4", "6.3", "6.4", "7.3", "7.4", "10.3", "100.3", "1000.3"}},
+		{[]string{"dsb", "hsb"}, Other, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.5", "1.6", "1.7", "1.8", "1.9", "2.0", "2.5", "2.6", "2.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"cs", "sk"}, One, []string{"1"}, []string(nil)},
+		{[]string{"cs", "sk"}, Few, []string{"2", "3", "4"}, []string(nil)},
+		{[]string{"cs", "sk"}, Many, []string(nil), []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"cs", "sk"}, Other, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"pl"}, One, []string{"1"}, []string(nil)},
+		{[]string{"pl"}, Few, []string{"2", "3", "4", "22", "23", "24", "32", "33", "34", "42", "43", "44", "52", "53", "54", "62", "102", "1002"}, []string(nil)},
+		{[]string{"pl"}, Many, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"pl"}, Other, []string(nil), []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"be"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "71.0", "81.0", "101.0", "1001.0"}},
+		{[]string{"be"}, Few, []string{"2", "3", "4", "22", "23", "24", "32", "33", "34", "42", "43", "44", "52", "53", "54", "62", "102", "1002"}, []string{"2.0", "3.0", "4.0", "22.0", "23.0", "24.0", "32.0", "33.0", "102.0", "1002.0"}},
+		{[]string{"be"}, Many, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "11.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"be"}, Other, []string(nil), []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.1", "100.1", "1000.1"}},
+		{[]string{"lt"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "71.0", "81.0", "101.0", "1001.0"}},
+		{[]string{"lt"}, Few, []string{"2", "3", "4", "5", "6", "7", "8", "9", "22", "23", "24", "25", "26", "27", "28", "29", "102", "1002"}, []string{"2.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "22.0", "102.0", "1002.0"}},
+		{[]string{"lt"}, Many, []string(nil), []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.1", "100.1", "1000.1"}},
+		{[]string{"lt"}, Other, []string{"0", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "30", "40", "50", "60", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "10.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ru", "uk"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string(nil)},
+		{[]string{"ru", "uk"}, Few, []string{"2", "3", "4", "22", "23", "24", "32", "33", "34", "42", "43", "44", "52", "53", "54", "62", "102", "1002"}, []string(nil)},
+		{[]string{"ru", "uk"}, Many, []string{"0", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"ru", "uk"}, Other, []string(nil), []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"sgs"}, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"}, []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "71.0", "81.0", "101.0", "1001.0"}},
+		{[]string{"sgs"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"sgs"}, Few, []string{"3", "4", "5", "6", "7", "8", "9", "22", "23", "24", "25", "26", "27", "28", "29", "32", "102", "1002"}, []string{"3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "22.0", "102.0", "1002.0"}},
+		{[]string{"sgs"}, Many, []string(nil), []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.1", "100.1", "1000.1"}},
+		{[]string{"sgs"}, Other, []string{"0", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "30", "40", "50", "60", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "10.0", "11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"br"}, One, []string{"1", "21", "31", "41", "51", "61", "81", "101", "1001"}, []string{"1.0", "21.0", "31.0", "41.0", "51.0", "61.0", "81.0", "101.0", "1001.0"}},
+		{[]string{"br"}, Two, []string{"2", "22", "32", "42", "52", "62", "82", "102", "1002"}, []string{"2.0", "22.0", "32.0", "42.0", "52.0", "62.0", "82.0", "102.0", "1002.0"}},
+		{[]string{"br"}, Few, []string{"3", "4", "9", "23", "24", "29", "33", "34", "39", "43", "44", "49", "103", "1003"}, []string{"3.0", "4.0", "9.0", "23.0", "24.0", "29.0", "33.0", "34.0", "103.0", "1003.0"}},
+		{[]string{"br"}, Many, []string{"1000000"}, []string{"1000000.0", "1000000.00", "1000000.000", "1000000.0000"}},
+		{[]string{"br"}, Other, []string{"0", "5", "6", "7", "8", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "100", "1000", "10000", "100000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.0", "100.0", "1000.0", "10000.0", "100000.0"}},
+		{[]string{"mt"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"mt"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"mt"}, Few, []string{"0", "3", "4", "5", "6", "7", "8", "9", "10", "103", "104", "105", "106", "107", "108", "109", "1003"}, []string{"0.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "103.0", "1003.0"}},
+		{[]string{"mt"}, Many, []string{"11", "12", "13", "14", "15", "16", "17", "18", "19", "111", "112", "113", "114", "115", "116", "117", "1011"}, []string{"11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "111.0", "1011.0"}},
+		{[]string{"mt"}, Other, []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "31", "32", "33", "34", "35", "100", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"ga"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"ga"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"ga"}, Few, []string{"3", "4", "5", "6"}, []string{"3.0", "4.0", "5.0", "6.0", "3.00", "4.00", "5.00", "6.00", "3.000", "4.000", "5.000", "6.000", "3.0000", "4.0000", "5.0000", "6.0000"}},
+		{[]string{"ga"}, Many, []string{"7", "8", "9", "10"}, []string{"7.0", "8.0", "9.0", "10.0", "7.00", "8.00", "9.00", "10.00", "7.000", "8.000", "9.000", "10.000", "7.0000", "8.0000", "9.0000", "10.0000"}},
+		{[]string{"ga"}, Other, []string{"0", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "100", "1000", "10000", "100000", "1000000"}, []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"gv"}, One, []string{"1", "11", "21", "31", "41", "51", "61", "71", "101", "1001"}, []string(nil)},
+		{[]string{"gv"}, Two, []string{"2", "12", "22", "32", "42", "52", "62", "72", "102", "1002"}, []string(nil)},
+		{[]string{"gv"}, Few, []string{"0", "20", "40", "60", "80", "100", "120", "140", "1000", "10000", "100000", "1000000"}, []string(nil)},
+		{[]string{"gv"}, Many, []string(nil), []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"gv"}, Other, []string{"3", "4", "5", "6", "7", "8", "9", "10", "13", "14", "15", "16", "17", "18", "19", "23", "103", "1003"}, []string(nil)},
+		{[]string{"kw"}, Zero, []string{"0"}, []string{"0.0", "0.00", "0.000", "0.0000"}},
+		{[]string{"kw"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"kw"}, Two, []string{"2", "22", "42", "62", "82", "102", "122", "142", "1000", "10000", "100000"}, []string{"2.0", "22.0", "42.0", "62.0", "82.0", "102.0", "122.0", "142.0", "1000.0", "10000.0", "100000.0"}},
+		{[]string{"kw"}, Few, []string{"3", "23", "43", "63", "83", "103", "123", "143", "1003"}, []string{"3.0", "23.0", "43.0", "63.0", "83.0", "103.0", "123.0", "143.0", "1003.0"}},
+		{[]string{"kw"}, Many, []string{"21", "41", "61", "81", "101", "121", "141", "161", "1001"}, []string{"21.0", "41.0", "61.0", "81.0", "101.0", "121.0", "141.0", "161.0", "1001.0"}},
+		{[]string{"kw"}, Other, []string{"4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "100", "1004", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.1", "1000000.0"}},
+		{[]string{"ar", "ars"}, Zero, []string{"0"}, []string{"0.0", "0.00", "0.000", "0.0000"}},
+		{[]string{"ar", "ars"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"ar", "ars"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"ar", "ars"}, Few, []string{"3", "4", "5", "6", "7", "8", "9", "10", "103", "104", "105", "106", "107", "108", "109", "110", "1003"}, []string{"3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0", "10.0", "103.0", "1003.0"}},
+		{[]string{"ar", "ars"}, Many, []string{"11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "26", "111", "1011"}, []string{"11.0", "12.0", "13.0", "14.0", "15.0", "16.0", "17.0", "18.0", "111.0", "1011.0"}},
+		{[]string{"ar", "ars"}, Other, []string{"100", "101", "102", "200", "201", "202", "300", "301", "302", "400", "401", "402", "500", "501", "502", "600", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.1", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+		{[]string{"cy"}, Zero, []string{"0"}, []string{"0.0", "0.00", "0.000", "0.0000"}},
+		{[]string{"cy"}, One, []string{"1"}, []string{"1.0", "1.00", "1.000", "1.0000"}},
+		{[]string{"cy"}, Two, []string{"2"}, []string{"2.0", "2.00", "2.000", "2.0000"}},
+		{[]string{"cy"}, Few, []string{"3"}, []string{"3.0", "3.00", "3.000", "3.0000"}},
+		{[]string{"cy"}, Many, []string{"6"}, []string{"6.0", "6.00", "6.000", "6.0000"}},
+		{[]string{"cy"}, Other, []string{"4", "5", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "100", "1000", "10000", "100000", "1000000"}, []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "10.0", "100.0", "1000.0", "10000.0", "100000.0", "1000000.0"}},
+	})
+}
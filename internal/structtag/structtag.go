@@ -0,0 +1,61 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package structtag parses the i18n tags of the struct fields that Localizer.LocalizeStruct localizes,
+// for both the i18n package and the extract command of i18n_cli.
+package structtag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Key is the key of the struct tags of the fields that LocalizeStruct localizes.
+const Key = "i18n"
+
+// Tag is the parsed i18n tag of a field, e.g. `i18n:"id=Unread,count=Count,default={{.Name}} has {{.Count}} messages"`.
+type Tag struct {
+	// ID is the id of the message.
+	ID string
+
+	// Count is the name of the sibling field that holds the plural count, if any.
+	Count string
+
+	// Default is the text of the message in the default language, if any.
+	// It is the last item of the tag so that it may contain commas.
+	Default string
+}
+
+// Parse parses the value of an i18n tag.
+func Parse(tag string) (*Tag, error) {
+	t := &Tag{}
+	for tag != "" {
+		var item string
+		if strings.HasPrefix(tag, "default=") {
+			item, tag = tag, ""
+		} else if i := strings.IndexByte(tag, ','); i >= 0 {
+			item, tag = tag[:i], tag[i+1:]
+		} else {
+			item, tag = tag, ""
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("expected key=value but got %q", item)
+		}
+		switch key, value := strings.TrimSpace(kv[0]), kv[1]; key {
+		case "id":
+			t.ID = strings.TrimSpace(value)
+		case "count":
+			t.Count = strings.TrimSpace(value)
+		case "default":
+			t.Default = value
+		default:
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+	if t.ID == "" {
+		return nil, fmt.Errorf("missing id")
+	}
+	return t, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package structtag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected *Tag
+	}{
+		{"id=Title", &Tag{ID: "Title"}},
+		{" id = Unread , count = Count", &Tag{ID: "Unread", Count: "Count"}},
+		{"id=Unread,count=Count,default={{.Name}} has {{.Count}}, or more, messages", &Tag{ID: "Unread", Count: "Count", Default: "{{.Name}} has {{.Count}}, or more, messages"}},
+		{"default=a=b,c,id=Text", &Tag{Default: "a=b,c,id=Text"}},
+	}
+	for _, test := range tests {
+		tag, err := Parse(test.tag)
+		if test.expected.ID == "" {
+			if err == nil {
+				t.Errorf("Parse(%q) accepted a tag without id", test.tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) returned error %s", test.tag, err)
+			continue
+		}
+		if !reflect.DeepEqual(tag, test.expected) {
+			t.Errorf("Parse(%q) = %+v; expected %+v", test.tag, tag, test.expected)
+		}
+	}
+	for _, tag := range []string{"", "Title", "id=Title,unknown=1"} {
+		if _, err := Parse(tag); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid tag", tag)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package i18n
 
 import (
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/hollson/i18n/internal/plural"
@@ -55,6 +56,8 @@ type LocalizeConfig struct {
 	TemplateData interface{}
 
 	// PluralCount确定使用哪种复数形式的消息。
+	//  支持整数、浮点数、Float、*big.Int、*big.Float、json.Number、
+	//  十进制字符串(包括"1.2c6"形式的紧凑写法)及返回十进制字符串的fmt.Stringer。
 	PluralCount interface{}
 
 	// DefaultMessage is used if the message is not found in any message files.
@@ -64,6 +67,11 @@ type LocalizeConfig struct {
 	Funcs template.FuncMap
 }
 
+// Float is a PluralCount for a floating point number
+// with an explicit number of visible fraction digits,
+// e.g. Float{Value: 1, Precision: 2} selects the plural form of "1.00".
+type Float = plural.Float
+
 type invalidPluralCountErr struct {
 	messageID   string
 	pluralCount interface{}
@@ -160,21 +168,48 @@ func (l *Localizer) LocalizeWithTag(lc *LocalizeConfig) (string, language.Tag, e
 		if err == nil {
 			err = err2
 		}
+		if _, ok := err2.(pluralFormNotFoundError); !ok {
+			l.notify(messageID, tag, ReasonTemplateError, err2)
+		}
 
 		// Attempt to fallback to "Other" pluralization in case translations are incomplete.
 		if pluralForm != plural.Other {
 			msg2, err3 := template.Execute(plural.Other, templateData, lc.Funcs)
 			if err3 == nil {
 				msg = msg2
+				l.notify(messageID, tag, ReasonFallbackPluralForm, err2)
 			}
 		}
 	}
 	return msg, tag, err
 }
 
-func (l *Localizer) getMessageTemplate(id string, defaultMessage *Message) (language.Tag, *MessageTemplate, error) {
+// LanguageTag returns the language tag of the bundle that best matches the language preferences of the Localizer.
+func (l *Localizer) LanguageTag() language.Tag {
+	return l.matchTag()
+}
+
+func (l *Localizer) matchTag() language.Tag {
 	_, i, _ := l.bundle.matcher.Match(l.tags...)
-	tag := l.bundle.tags[i]
+	return l.bundle.tags[i]
+}
+
+// notify notifies the bundle's observers about the message id resolved in resolvedTag.
+func (l *Localizer) notify(id string, resolvedTag language.Tag, reason Reason, err error) {
+	if len(l.bundle.observers) == 0 {
+		return
+	}
+	l.bundle.notify(&Event{
+		MessageID:    id,
+		RequestedTag: l.matchTag(),
+		ResolvedTag:  resolvedTag,
+		Reason:       reason,
+		Err:          err,
+	})
+}
+
+func (l *Localizer) getMessageTemplate(id string, defaultMessage *Message) (language.Tag, *MessageTemplate, error) {
+	tag := l.matchTag()
 	mt := l.bundle.getMessageTemplate(tag, id)
 	if mt != nil {
 		return tag, mt, nil
@@ -182,22 +217,29 @@ func (l *Localizer) getMessageTemplate(id string, defaultMessage *Message) (lang
 
 	if tag == l.bundle.defaultLanguage {
 		if defaultMessage == nil {
-			return language.Und, nil, &MessageNotFoundErr{tag: tag, messageID: id}
+			err := &MessageNotFoundErr{tag: tag, messageID: id}
+			l.notify(id, language.Und, ReasonMissing, err)
+			return language.Und, nil, err
 		}
+		l.notify(id, tag, ReasonFallbackDefaultMessage, nil)
 		return tag, NewMessageTemplate(defaultMessage), nil
 	}
 
 	// Fallback to default language in bundle.
+	err := &MessageNotFoundErr{tag: tag, messageID: id}
 	mt = l.bundle.getMessageTemplate(l.bundle.defaultLanguage, id)
 	if mt != nil {
-		return l.bundle.defaultLanguage, mt, &MessageNotFoundErr{tag: tag, messageID: id}
+		l.notify(id, l.bundle.defaultLanguage, ReasonFallbackLanguage, err)
+		return l.bundle.defaultLanguage, mt, err
 	}
 
 	// Fallback to default message.
 	if defaultMessage == nil {
-		return language.Und, nil, &MessageNotFoundErr{tag: tag, messageID: id}
+		l.notify(id, language.Und, ReasonMissing, err)
+		return language.Und, nil, err
 	}
-	return l.bundle.defaultLanguage, NewMessageTemplate(defaultMessage), &MessageNotFoundErr{tag: tag, messageID: id}
+	l.notify(id, l.bundle.defaultLanguage, ReasonFallbackDefaultMessage, err)
+	return l.bundle.defaultLanguage, NewMessageTemplate(defaultMessage), err
 }
 
 func (l *Localizer) pluralForm(tag language.Tag, operands *plural.Operands) plural.Form {
@@ -211,7 +253,99 @@ func (l *Localizer) pluralForm(tag language.Tag, operands *plural.Operands) plur
 func (l *Localizer) MustLocalize(lc *LocalizeConfig) string {
 	localized, err := l.Localize(lc)
 	if err != nil {
-		return ""
+		panic(err)
 	}
 	return localized
 }
+
+// LocalizeOrDefault is similar to Localize, except it never fails.
+// It returns the best effort localized message, e.g. the message in the default language,
+// or the placeholder of the bundle's PlaceholderFunc if there is none.
+// Errors are passed to the bundle's error handler instead.
+func (l *Localizer) LocalizeOrDefault(lc *LocalizeConfig) string {
+	localized, _, err := l.LocalizeWithTag(lc)
+	if err == nil {
+		return localized
+	}
+	if l.bundle.errorHandler != nil {
+		l.bundle.errorHandler(err)
+	}
+	if localized != "" {
+		return localized
+	}
+	messageID := lc.MessageID
+	if lc.DefaultMessage != nil {
+		messageID = lc.DefaultMessage.ID
+	}
+	if l.bundle.placeholderFunc != nil {
+		return l.bundle.placeholderFunc(messageID)
+	}
+	return DefaultPlaceholder(messageID)
+}
+
+// LocalizedMessage is a message resolved by LocalizeAll.
+type LocalizedMessage struct {
+	// Tag is the language tag the message was resolved in.
+	Tag language.Tag `json:"tag"`
+
+	// Text is the text of a message without template actions and plural forms other than "other",
+	// which clients can show as it is.
+	Text string `json:"text,omitempty"`
+
+	// Templates are the raw templates of the other messages by plural form,
+	// which clients render with their template data and plural count.
+	Templates map[PluralForm]string `json:"templates,omitempty"`
+
+	// LeftDelim and RightDelim are the delimiters of the actions of Templates if they are not the default ones.
+	LeftDelim  string `json:"leftDelim,omitempty"`
+	RightDelim string `json:"rightDelim,omitempty"`
+}
+
+// LocalizeAll returns the messages whose id is prefix or in the dotted namespace prefix, e.g. "page.home"
+// for "page.home.title" and "page.home.intro", resolved like Localize by id.
+// All messages are returned if prefix is empty, and messages that cannot be resolved are left out.
+// Unlike Localize, LocalizeAll does not notify the observers of the bundle about messages that fall back
+// to the default language, since clients fetch all messages of a page whether they show them or not.
+func (l *Localizer) LocalizeAll(prefix string) map[string]*LocalizedMessage {
+	prefix = strings.TrimSuffix(prefix, ".")
+	matchTag := l.matchTag()
+	ids := map[string]bool{}
+	for _, tag := range []language.Tag{matchTag, l.bundle.defaultLanguage} {
+		for id := range l.bundle.messageTemplates[tag] {
+			if prefix == "" || id == prefix || strings.HasPrefix(id, prefix+".") {
+				ids[id] = true
+			}
+		}
+	}
+
+	messages := make(map[string]*LocalizedMessage, len(ids))
+	for id := range ids {
+		tag, template := matchTag, l.bundle.getMessageTemplate(matchTag, id)
+		if template == nil {
+			tag, template = l.bundle.defaultLanguage, l.bundle.getMessageTemplate(l.bundle.defaultLanguage, id)
+		}
+		if template == nil {
+			continue
+		}
+		lm := &LocalizedMessage{Tag: tag}
+		if t := template.PluralTemplates[plural.Other]; len(template.PluralTemplates) == 1 && t != nil && !hasActions(t.Src, t.LeftDelim) {
+			lm.Text = t.Src
+		} else {
+			lm.Templates = make(map[PluralForm]string, len(template.PluralTemplates))
+			for pluralForm, t := range template.PluralTemplates {
+				lm.Templates[pluralForm] = t.Src
+			}
+			lm.LeftDelim, lm.RightDelim = template.LeftDelim, template.RightDelim
+		}
+		messages[id] = lm
+	}
+	return messages
+}
+
+// hasActions tells whether the template src may have actions.
+func hasActions(src, leftDelim string) bool {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	return strings.Contains(src, leftDelim)
+}
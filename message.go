@@ -6,6 +6,7 @@ package i18n
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -25,6 +26,10 @@ type Message struct {
 	// 消息描述
 	Desc string
 
+	// 消息的上下文(类似gettext的msgctxt),用于区分源文本相同但含义不同的消息,
+	// 如按钮上的动词“Open”与状态标签上的形容词“Open”
+	Context string
+
 	// Go模板的左分隔符
 	LeftDelim string
 
@@ -48,8 +53,42 @@ type Message struct {
 
 	// CLDR复数形式“Other”的消息内容
 	Other string
+
+	// 翻译已过期(fuzzy),即源消息在翻译之后发生了变化,需要译者重新审核
+	Fuzzy bool
+
+	// 翻译所依据的源消息内容,以复数形式(如“one”,“other”)为键,
+	// 源消息变化后译者可以据此对比差异
+	Source map[string]string
+
+	// 机器翻译的草稿,需要译者审核
+	MachineTranslated bool
+
+	// 译文的最大长度(字符数,不含模板动作),0表示不限制,lint会检查各语言的翻译
+	MaxLength int
+
+	// 截图或URL等参考资料,帮助译者了解消息的使用场景
+	Reference string
+
+	// 消息的标签,如所属的页面或功能
+	Tags []string
+
+	// 不可翻译的词语(如产品名),译文中必须原样保留
+	DoNotTranslate []string
+
+	// 占位符的说明,以占位符名(如“Name”)为键
+	Placeholders map[string]string
+
+	// 翻译的审核状态: 空,“reviewed”(已审核)或“approved”(已批准)
+	Status string
 }
 
+// 翻译的审核状态
+const (
+	StatusReviewed = "reviewed"
+	StatusApproved = "approved"
+)
+
 func (m *Message) String() string {
 	return fmt.Sprintf("%+v", *m)
 }
@@ -84,6 +123,8 @@ func (m *Message) unmarshalInterface(v interface{}) error {
 			m.ID = v
 		case "description":
 			m.Desc = v
+		case "context":
+			m.Context = v
 		case "hash":
 			m.Hash = v
 		case "leftdelim":
@@ -102,11 +143,88 @@ func (m *Message) unmarshalInterface(v interface{}) error {
 			m.Many = v
 		case "other":
 			m.Other = v
+		case "fuzzy":
+			fuzzy, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected value for key %q be a bool but got %q", k, v)
+			}
+			m.Fuzzy = fuzzy
+		case "machine_translated":
+			machineTranslated, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected value for key %q be a bool but got %q", k, v)
+			}
+			m.MachineTranslated = machineTranslated
+		case "source":
+			m.setSource("other", v)
+		case "maxlength":
+			maxLength, err := strconv.Atoi(v)
+			if err != nil || maxLength < 0 {
+				return fmt.Errorf("expected value for key %q be a non-negative integer but got %q", k, v)
+			}
+			m.MaxLength = maxLength
+		case "reference":
+			m.Reference = v
+		case "status":
+			switch v {
+			case "", StatusReviewed, StatusApproved:
+				m.Status = v
+			default:
+				return fmt.Errorf("expected value for key %q be %q or %q but got %q", k, StatusReviewed, StatusApproved, v)
+			}
+		default:
+			lk := strings.ToLower(k)
+			switch {
+			case strings.HasPrefix(lk, sourcePrefix):
+				m.setSource(lk[len(sourcePrefix):], v)
+			case strings.HasPrefix(lk, placeholdersPrefix):
+				if m.Placeholders == nil {
+					m.Placeholders = map[string]string{}
+				}
+				m.Placeholders[k[len(placeholdersPrefix):]] = v
+			case strings.HasPrefix(lk, tagsPrefix):
+				if m.Tags, err = setItem(m.Tags, lk[len(tagsPrefix):], v, len(strdata)); err != nil {
+					return fmt.Errorf("invalid key %q: %s", k, err)
+				}
+			case strings.HasPrefix(lk, doNotTranslatePrefix):
+				if m.DoNotTranslate, err = setItem(m.DoNotTranslate, lk[len(doNotTranslatePrefix):], v, len(strdata)); err != nil {
+					return fmt.Errorf("invalid key %q: %s", k, err)
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// Prefixes of the keys of maps and lists when flattening a message,
+// e.g. the plural forms of the "source" key or the items of the "tags" key by index.
+const (
+	sourcePrefix         = "source."
+	placeholdersPrefix   = "placeholders."
+	tagsPrefix           = "tags."
+	doNotTranslatePrefix = "donottranslate."
+)
+
+// setItem sets the item of list at index, a position in a list of at most size items.
+func setItem(list []string, index string, item string, size int) ([]string, error) {
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= size {
+		return list, fmt.Errorf("invalid index %q", index)
+	}
+	for len(list) <= i {
+		list = append(list, "")
+	}
+	list[i] = item
+	return list, nil
+}
+
+func (m *Message) setSource(form, src string) {
+	if m.Source == nil {
+		m.Source = map[string]string{}
+	}
+	m.Source[form] = src
+}
+
 type keyTypeErr struct {
 	key interface{}
 }
@@ -174,11 +292,52 @@ func stringSubmap(k string, v interface{}, strdata map[string]string) error {
 		}
 		return nil
 	}
+	if lk := strings.ToLower(k); lk == "source" || lk == "placeholders" {
+		switch vt := v.(type) {
+		case string:
+			strdata[k] = vt
+		default:
+			sub, err := stringMap(v)
+			if err != nil {
+				return err
+			}
+			for key, value := range sub {
+				strdata[lk+"."+key] = value
+			}
+		}
+		return nil
+	}
 
 	switch vt := v.(type) {
 	case string:
 		strdata[k] = vt
 		return nil
+	case bool:
+		strdata[k] = strconv.FormatBool(vt)
+		return nil
+	case int:
+		strdata[k] = strconv.Itoa(vt)
+		return nil
+	case int64:
+		strdata[k] = strconv.FormatInt(vt, 10)
+		return nil
+	case float64:
+		strdata[k] = strconv.FormatFloat(vt, 'f', -1, 64)
+		return nil
+	case []string:
+		for i, item := range vt {
+			strdata[fmt.Sprintf("%s.%d", strings.ToLower(k), i)] = item
+		}
+		return nil
+	case []interface{}:
+		for i, item := range vt {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("expected items of key %q be strings but got %#v", k, item)
+			}
+			strdata[fmt.Sprintf("%s.%d", strings.ToLower(k), i)] = s
+		}
+		return nil
 	case nil:
 		return nil
 	default:
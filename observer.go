@@ -0,0 +1,78 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Reason tells why a Localizer could not localize a message as requested.
+type Reason string
+
+// All reasons an Observer is notified for.
+const (
+	// ReasonMissing means the message was found neither in the bundle nor as a DefaultMessage.
+	ReasonMissing Reason = "missing"
+
+	// ReasonFallbackLanguage means the message was missing in the requested language
+	// and the bundle's default language was used instead.
+	ReasonFallbackLanguage Reason = "fallback_language"
+
+	// ReasonFallbackDefaultMessage means the message was missing in the bundle
+	// and the DefaultMessage of the LocalizeConfig was used instead.
+	ReasonFallbackDefaultMessage Reason = "fallback_default_message"
+
+	// ReasonFallbackPluralForm means the message has no template for the plural form of PluralCount
+	// and the "other" form was used instead.
+	ReasonFallbackPluralForm Reason = "fallback_plural_form"
+
+	// ReasonTemplateError means the message template failed to parse or execute.
+	ReasonTemplateError Reason = "template_error"
+)
+
+// Event describes a message that a Localizer could not localize as requested.
+type Event struct {
+	// MessageID is the id of the message.
+	MessageID string
+
+	// RequestedTag is the language tag the Localizer matched in the bundle.
+	RequestedTag language.Tag
+
+	// ResolvedTag is the language tag of the message that was used,
+	// or language.Und if no message was found.
+	ResolvedTag language.Tag
+
+	// Reason tells what happened.
+	Reason Reason
+
+	// Err is the error that caused the event, if any.
+	Err error
+}
+
+// Observer is notified each time a Localizer falls back or fails to localize a message,
+// e.g. to export metrics or to log missing translations.
+// Observers are called synchronously from the goroutine calling the Localizer.
+type Observer interface {
+	Observe(e *Event)
+}
+
+// ObserverFunc is an adapter to allow the use of ordinary functions as Observers.
+type ObserverFunc func(e *Event)
+
+// Observe calls f(e).
+func (f ObserverFunc) Observe(e *Event) {
+	f(e)
+}
+
+// RegisterObserver adds an Observer to the bundle.
+func (b *Bundle) RegisterObserver(observer Observer) {
+	b.observers = append(b.observers, observer)
+}
+
+func (b *Bundle) notify(e *Event) {
+	for _, o := range b.observers {
+		o.Observe(e)
+	}
+}
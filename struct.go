@@ -0,0 +1,149 @@
+// Copyright 2021 Hollson. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hollson/i18n/internal/structtag"
+)
+
+// LocalizeStruct localizes the exported string fields of v that have an i18n tag, e.g.
+//
+//	type Inbox struct {
+//		Name  string
+//		Count int
+//		Title string `i18n:"id=Unread,count=Count,default={{.Name}} has {{.Count}} unread messages"`
+//	}
+//
+// The message is executed with the struct of the field as template data,
+// and the value of the sibling field named by count, if any, as plural count.
+// The text of the message in the default language is default, or else the current value of the field,
+// so the field may hold the text in the default language to be replaced.
+//
+// Fields tagged i18n:"-" are skipped.
+// v must be a pointer. Nested structs, pointers, interfaces, slices, arrays and maps are localized recursively.
+// All fields are localized even if some fail, and the first error is returned.
+func (l *Localizer) LocalizeStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer but got %T", v)
+	}
+	sl := &structLocalizer{localizer: l, visited: map[uintptr]bool{}}
+	sl.localize(rv)
+	return sl.err
+}
+
+// structLocalizer walks the values of LocalizeStruct.
+type structLocalizer struct {
+	localizer *Localizer
+
+	// visited are the pointers already walked, so that cyclic values terminate.
+	visited map[uintptr]bool
+	err     error
+}
+
+func (sl *structLocalizer) fail(err error) {
+	if sl.err == nil {
+		sl.err = err
+	}
+}
+
+// localize localizes the value rv, which must be settable if it is a struct, an array or an interface.
+func (sl *structLocalizer) localize(rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() || sl.visited[rv.Pointer()] {
+			return
+		}
+		sl.visited[rv.Pointer()] = true
+		sl.localize(rv.Elem())
+	case reflect.Interface:
+		if rv.IsNil() {
+			return
+		}
+		sl.localizeCopy(rv.Elem(), rv.Set)
+	case reflect.Struct:
+		sl.localizeStruct(rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			sl.localize(rv.Index(i))
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			sl.localizeCopy(iter.Value(), func(v reflect.Value) {
+				rv.SetMapIndex(key, v)
+			})
+		}
+	}
+}
+
+// localizeCopy localizes a copy of the value rv that cannot be set, e.g. a map value, and stores it with set.
+func (sl *structLocalizer) localizeCopy(rv reflect.Value, set func(reflect.Value)) {
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Array, reflect.Interface:
+		copied := reflect.New(rv.Type()).Elem()
+		copied.Set(rv)
+		sl.localize(copied)
+		set(copied)
+	default:
+		sl.localize(rv)
+	}
+}
+
+func (sl *structLocalizer) localizeStruct(rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag, ok := field.Tag.Lookup(structtag.Key)
+		if tag == "-" {
+			continue
+		}
+		if !ok {
+			sl.localize(rv.Field(i))
+			continue
+		}
+		if err := sl.localizeField(rv, field, tag); err != nil {
+			sl.fail(fmt.Errorf("field %s.%s: %s", t.Name(), field.Name, err))
+		}
+	}
+}
+
+// localizeField localizes the field of the struct rv with the i18n tag.
+func (sl *structLocalizer) localizeField(rv reflect.Value, field reflect.StructField, tag string) error {
+	st, err := structtag.Parse(tag)
+	if err != nil {
+		return fmt.Errorf("invalid i18n tag: %s", err)
+	}
+	if field.Type.Kind() != reflect.String {
+		return fmt.Errorf("expected a string field but got %s", field.Type)
+	}
+	fv := rv.FieldByIndex(field.Index)
+	lc := &LocalizeConfig{MessageID: st.ID, TemplateData: rv.Interface()}
+	if text := st.Default; text != "" || fv.String() != "" {
+		if text == "" {
+			text = fv.String()
+		}
+		lc.DefaultMessage = &Message{ID: st.ID, Other: text}
+	}
+	if st.Count != "" {
+		count, ok := rv.Type().FieldByName(st.Count)
+		if !ok || count.PkgPath != "" {
+			return fmt.Errorf("unknown count field %q", st.Count)
+		}
+		lc.PluralCount = rv.FieldByIndex(count.Index).Interface()
+	}
+	text, _, err := sl.localizer.LocalizeWithTag(lc)
+	if text != "" {
+		fv.SetString(text)
+	}
+	return err
+}